@@ -0,0 +1,97 @@
+package gohttpclient
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// RequestSpec describes a single request of a batch
+	RequestSpec struct {
+		Method   string
+		Endpoint string
+		Options  []Option
+	}
+
+	// Result holds the outcome of a RequestSpec, in the same position as the spec
+	Result struct {
+		Response *Response
+		Err      error
+	}
+
+	BatchMode int
+
+	BatchOption func(b *batch)
+
+	batch struct {
+		mode BatchMode
+	}
+)
+
+const (
+	// BatchCollectAll runs every request and reports each error in its Result
+	BatchCollectAll BatchMode = iota
+	// BatchFailFast cancels the remaining and in-flight requests after the first error
+	BatchFailFast
+)
+
+// WithBatchMode sets how a batch reacts to failing requests, BatchCollectAll by default
+func WithBatchMode(mode BatchMode) BatchOption {
+	return func(b *batch) {
+		b.mode = mode
+	}
+}
+
+// Batch func sends the requests in parallel with at most concurrency requests in flight.
+// Results keep the order of reqs. In BatchFailFast mode the first error is returned as well,
+// requests who did not finish carry the cancellation error in their Result.
+func (c *Client) Batch(ctx context.Context, reqs []RequestSpec, concurrency int, opts ...BatchOption) ([]Result, error) {
+	b := &batch{mode: BatchCollectAll}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if concurrency <= 0 || concurrency > len(reqs) {
+		concurrency = len(reqs)
+	}
+
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	results := make([]Result, len(reqs))
+	sem := make(chan struct{}, concurrency)
+
+	for idx, spec := range reqs {
+		select {
+		case sem <- struct{}{}:
+		case <-batchCtx.Done():
+			results[idx] = Result{Err: batchCtx.Err()}
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, spec RequestSpec) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := c.Do(batchCtx, spec.Method, spec.Endpoint, spec.Options...)
+			results[idx] = Result{Response: res, Err: err}
+
+			if err != nil && b.mode == BatchFailFast {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(idx, spec)
+	}
+
+	wg.Wait()
+	return results, firstErr
+}
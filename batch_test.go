@@ -0,0 +1,146 @@
+package gohttpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestBatchSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(TestBatchSuite))
+}
+
+func (s *TestBatchSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestBatchSuite) Test_Batch_ShouldPreserveOrder() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(r.URL.Query().Get("id"))
+		time.Sleep(time.Duration(10-id) * time.Millisecond)
+		w.Write([]byte(r.Header.Get("X-Shard") + r.URL.Query().Get("id")))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	reqs := make([]RequestSpec, 10)
+	for i := range reqs {
+		reqs[i] = RequestSpec{
+			Method:   http.MethodGet,
+			Endpoint: "/",
+			Options:  []Option{WithHeader("X-Shard", "shard-"), WithQuery("id", strconv.Itoa(i))},
+		}
+	}
+
+	// Act
+	results, err := client.Batch(s.ctx, reqs, 4)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 10)
+	for i, result := range results {
+		s.NoError(result.Err)
+		s.Equal("shard-"+strconv.Itoa(i), string(result.Response.Body()))
+	}
+	s.Empty(client.headers)
+	s.Empty(client.query)
+}
+
+func (s *TestBatchSuite) Test_Batch_ShouldBoundConcurrency() {
+	// Arrange
+	var inFlight, peak int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	reqs := make([]RequestSpec, 12)
+	for i := range reqs {
+		reqs[i] = RequestSpec{Method: http.MethodGet, Endpoint: "/"}
+	}
+
+	// Act
+	results, err := client.Batch(s.ctx, reqs, 3)
+
+	// Assert
+	s.NoError(err)
+	s.Len(results, 12)
+	s.LessOrEqual(atomic.LoadInt32(&peak), int32(3))
+}
+
+func (s *TestBatchSuite) Test_Batch_WhenCollectAll_ShouldReturnEveryError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	reqs := []RequestSpec{
+		{Method: http.MethodGet, Endpoint: "/"},
+		{Method: "BAD METHOD", Endpoint: "/"},
+		{Method: http.MethodPost, Endpoint: "/", Options: []Option{WithBody([]byte("body"))}},
+	}
+
+	// Act
+	results, err := client.Batch(s.ctx, reqs, 0)
+
+	// Assert
+	s.NoError(err)
+	s.NoError(results[0].Err)
+	s.Error(results[1].Err)
+	s.Nil(results[1].Response)
+	s.NoError(results[2].Err)
+}
+
+func (s *TestBatchSuite) Test_Batch_WhenFailFast_ShouldCancelInFlightRequests() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	reqs := []RequestSpec{
+		{Method: http.MethodGet, Endpoint: "/slow"},
+		{Method: "BAD METHOD", Endpoint: "/"},
+		{Method: http.MethodGet, Endpoint: "/slow"},
+		{Method: http.MethodGet, Endpoint: "/slow"},
+	}
+
+	// Act
+	start := time.Now()
+	results, err := client.Batch(s.ctx, reqs, 2, WithBatchMode(BatchFailFast))
+
+	// Assert
+	s.Error(err)
+	s.Less(int64(time.Since(start)), int64(time.Second))
+	for _, result := range results {
+		s.Error(result.Err)
+	}
+}
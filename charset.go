@@ -0,0 +1,120 @@
+package gohttpclient
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// charsetUnmarshaler is a codec who decodes bodies of any charset itself,
+	// instead of being handed a body converted to UTF-8
+	charsetUnmarshaler interface {
+		unmarshalCharset(data []byte, charset string, v any) error
+	}
+
+	// latin1Reader converts ISO-8859-1 to UTF-8
+	latin1Reader struct {
+		r       io.Reader
+		pending []byte
+	}
+)
+
+// contentCharset returns the lower cased charset parameter of a Content-Type header value
+func contentCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(params["charset"])
+}
+
+func isUTF8Charset(charset string) bool {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return true
+	}
+
+	return false
+}
+
+func isLatin1Charset(charset string) bool {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "iso8859-1", "iso_8859-1", "latin1", "l1":
+		return true
+	}
+
+	return false
+}
+
+// charsetReader returns a reader who converts input from charset to UTF-8
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch {
+	case isUTF8Charset(charset):
+		return input, nil
+	case isLatin1Charset(charset):
+		return &latin1Reader{r: input}, nil
+	}
+
+	return nil, errors.Errorf("unsupported charset %q", charset)
+}
+
+// toUTF8 converts data from charset to UTF-8
+func toUTF8(charset string, data []byte) ([]byte, error) {
+	if isUTF8Charset(charset) {
+		return data, nil
+	}
+
+	reader, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(reader)
+}
+
+func (r *latin1Reader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		buf := make([]byte, (len(p)+1)/2)
+		n, err := r.r.Read(buf)
+		for _, b := range buf[:n] {
+			r.pending = utf8.AppendRune(r.pending, rune(b))
+		}
+
+		if len(r.pending) == 0 {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// decodeXML decodes data into v. Data in a charset other than UTF-8 is converted first, and the
+// encoding of the XML declaration is then ignored. Without a charset the declaration is honored.
+func decodeXML(data []byte, charset string, v any) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+
+	if charset != "" {
+		utf8Data, err := toUTF8(charset, data)
+		if err != nil {
+			return err
+		}
+
+		decoder = xml.NewDecoder(bytes.NewReader(utf8Data))
+		decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+			return input, nil
+		}
+	}
+
+	return decoder.Decode(v)
+}
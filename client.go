@@ -3,8 +3,11 @@ package gohttpclient
 import (
 	"bytes"
 	"context"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,11 +18,27 @@ type (
 	Client struct {
 		baseUrl    string
 		httpClient *http.Client
+		// customClient is set when the http client was given by WithCustomHttpClient
+		customClient bool
 
 		headers map[string]Header
 		query   map[string]string
 		body    []byte
 		timeout time.Duration
+		hedging hedging
+
+		bodyEncoding    string
+		autoCompression autoCompression
+
+		timeouts  phaseTimeouts
+		redirects *RedirectPolicy
+
+		maxLineSize         int
+		maxResponseSize     int64
+		maxDecompressedSize int64
+		netDialer           *net.Dialer
+
+		err error
 	}
 
 	// Clienter is a interface who calls the methods
@@ -37,7 +56,9 @@ const (
 
 // New func returns a Client struct
 func New(baseUrl string, opts ...ClientOption) *Client {
-	httpClient := &http.Client{Timeout: DEFAULT_TIMEOUT}
+	// every client owns its transport, so tuning or closing one never touches another
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	httpClient := &http.Client{Timeout: DEFAULT_TIMEOUT, Transport: transport}
 	client := &Client{httpClient: httpClient, baseUrl: baseUrl, timeout: DEFAULT_TIMEOUT}
 
 	for _, opt := range opts {
@@ -47,147 +68,137 @@ func New(baseUrl string, opts ...ClientOption) *Client {
 	return client
 }
 
-// Get func returns a request
+// Get func returns a request, a body is only sent when one is given
 func (c *Client) Get(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.Do(ctx, http.MethodGet, endpoint, opts...)
+}
 
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
+// Head func returns a request, the response has an empty body
+func (c *Client) Head(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
+	return c.Do(ctx, http.MethodHead, endpoint, opts...)
 }
 
 // Post func returns a request
 func (c *Client) Post(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseUrl+endpoint, bytes.NewBuffer(c.body))
-	if err != nil {
-		return nil, err
-	}
-
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
-
+	return c.Do(ctx, http.MethodPost, endpoint, opts...)
 }
 
 // Put func returns a request
 func (c *Client) Put(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.baseUrl+endpoint, bytes.NewBuffer(c.body))
-	if err != nil {
-		return nil, err
-	}
-
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
+	return c.Do(ctx, http.MethodPut, endpoint, opts...)
 }
 
 // Patch func returns a request
 func (c *Client) Patch(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, c.baseUrl+endpoint, bytes.NewBuffer(c.body))
-	if err != nil {
-		return nil, err
-	}
-
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
+	return c.Do(ctx, http.MethodPatch, endpoint, opts...)
 }
 
 // Delete func returns a request
 func (c *Client) Delete(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
+	return c.Do(ctx, http.MethodDelete, endpoint, opts...)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseUrl+endpoint, bytes.NewBuffer(c.body))
-	if err != nil {
-		return nil, err
-	}
+// Connect func asks the proxy at the base url to open a tunnel to endpoint, a host:port pair,
+// and returns the tunnel as a connection after a 2xx response
+func (c *Client) Connect(ctx context.Context, endpoint string, opts ...Option) (net.Conn, error) {
+	rc := c.withOpts(opts...)
+	return rc.tunnel(ctx, endpoint)
+}
 
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
+// Options func returns a request, a body is only sent when one is given
+func (c *Client) Options(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
+	return c.Do(ctx, http.MethodOptions, endpoint, opts...)
+}
+
+// Trace func returns a request, a body is only sent when one is given
+func (c *Client) Trace(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
+	return c.Do(ctx, http.MethodTrace, endpoint, opts...)
 }
 
-func (c *Client) Connect(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
+// PrepareRequest func returns a request
+func (c *Client) PrepareRequest(ctx context.Context, method, endpoint string, opts ...Option) (*http.Request, error) {
+	rc := c.withOpts(opts...)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodConnect, c.baseUrl+endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, method, rc.baseUrl+endpoint, bytes.NewBuffer(rc.body))
 	if err != nil {
 		return nil, err
 	}
 
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
+	return rc.prepareReq(req)
 }
 
-func (c *Client) Options(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
+// Do func sends a request with any method, WebDAV and custom ones like PROPFIND or PURGE included.
+// The body is only attached when one is given.
+func (c *Client) Do(ctx context.Context, method, endpoint string, opts ...Option) (*Response, error) {
+	rc := c.withOpts(opts...)
+
+	var body io.Reader
+	if rc.body != nil {
+		body = bytes.NewBuffer(rc.body)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodOptions, c.baseUrl+endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, method, rc.baseUrl+endpoint, body)
 	if err != nil {
 		return nil, err
 	}
 
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
-}
-
-func (c *Client) Trace(ctx context.Context, endpoint string, opts ...Option) (*Response, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodTrace, c.baseUrl+endpoint, nil)
+	prepReq, err := rc.prepareReq(req)
 	if err != nil {
 		return nil, err
 	}
 
-	prepReq := c.prepareReq(req)
-	return c.sendReq(ctx, prepReq)
+	return rc.sendReq(ctx, prepReq)
 }
 
-// PrepareRequest func returns a request
-func (c *Client) PrepareRequest(ctx context.Context, method, endpoint string, opts ...Option) (*http.Request, error) {
-	clear := c.initOpts(opts...)
-	defer clear()
+// Send func sends a request built elsewhere, such as by PrepareRequest, with the timeout, hedging
+// and decompression of the client. The request is sent as it is, headers and query of the client are
+// only added by PrepareRequest.
+func (c *Client) Send(req *http.Request) (*Response, error) {
+	if req == nil {
+		return nil, errors.New("request is nil")
+	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+endpoint, bytes.NewBuffer(c.body))
-	if err != nil {
-		return nil, err
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	if req.URL == nil || req.URL.Host == "" {
+		return nil, errors.New("request url has no host")
 	}
 
-	return c.prepareReq(req), nil
+	// the header of the caller's request is not changed
+	ctx := req.Context()
+	return c.sendReq(ctx, req.Clone(ctx))
 }
 
-func (c *Client) initOpts(opts ...Option) func() {
-	for _, opt := range opts {
-		opt(c)
+// withOpts returns a copy of the client who carries the request options,
+// so concurrent requests never share headers, query or body
+func (c *Client) withOpts(opts ...Option) *Client {
+	rc := *c
+	rc.headers = make(map[string]Header, len(c.headers))
+	for key, header := range c.headers {
+		rc.headers[key] = header
 	}
 
-	return func() {
-		for key, header := range c.headers {
-			if !header.IsDefault {
-				delete(c.headers, key)
-			}
-		}
+	rc.query = make(map[string]string, len(c.query))
+	for key, value := range c.query {
+		rc.query[key] = value
+	}
 
-		c.query = make(map[string]string)
-		c.body = nil
+	for _, opt := range opts {
+		opt(&rc)
 	}
+
+	rc.compressBody()
+	return &rc
 }
 
-func (c *Client) prepareReq(req *http.Request) *http.Request {
+func (c *Client) prepareReq(req *http.Request) (*http.Request, error) {
+	// a client option who failed makes every request fail
+	if c.err != nil {
+		return nil, c.err
+	}
+
 	// set headers
 	for key, header := range c.headers {
 		req.Header.Set(key, header.Value)
@@ -200,23 +211,110 @@ func (c *Client) prepareReq(req *http.Request) *http.Request {
 	}
 
 	req.URL.RawQuery = q.Encode()
-	return req
+	return req, nil
 }
 
 func (c *Client) sendReq(ctx context.Context, req *http.Request) (*Response, error) {
-	reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	reqCtx, cancel := context.WithTimeout(ctx, c.requestTimeout())
+	defer cancel()
+
+	// http.Transport only decodes gzip and only when the caller has not asked for an encoding,
+	// a range of a compressed representation is not decodable on its own so ranges are left alone
+	if req.Header.Get("Accept-Encoding") == "" && req.Header.Get("Range") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	var (
+		res *Response
+		err error
+	)
+
+	if c.hedging.delay > 0 && isIdempotent(req.Method) && isReplayable(req) {
+		res, err = c.sendHedged(reqCtx, req)
+	} else {
+		res, err = c.roundTrip(reqCtx, req)
+	}
+
+	if err != nil {
+		return nil, c.totalErr(ctx, reqCtx, err)
+	}
+
+	return res, nil
+}
+
+func (c *Client) roundTrip(ctx context.Context, req *http.Request) (*Response, error) {
+	ctx, timer, cancel := c.withPhases(ctx)
 	defer cancel()
 
-	res, err := c.httpClient.Do(req.WithContext(reqCtx))
+	var chain []*url.URL
+	httpClient := c.untimedClient()
+	httpClient.CheckRedirect = c.checkRedirect(&chain)
+
+	// the context carries the request timeout, so the shared client timeout is not applied twice
+	start := time.Now()
+	res, err := httpClient.Do(req.WithContext(ctx))
+	timer.stop()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to send request")
+		return nil, phaseErr(timer, errors.Wrap(err, "failed to send request"))
 	}
 
 	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
+
+	// the Content-Length of a HEAD response describes a body who is never sent
+	if c.maxResponseSize > 0 && req.Method != http.MethodHead && res.ContentLength > c.maxResponseSize {
+		return nil, c.tooLarge(res)
+	}
+
+	var reader io.Reader = res.Body
+	if c.timeouts.idleRead > 0 {
+		reader = &idleReader{r: res.Body, timer: timer, timeout: c.timeouts.idleRead}
+	}
+
+	if c.maxResponseSize > 0 {
+		reader = io.LimitReader(reader, c.maxResponseSize+1)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, phaseErr(timer, errors.Wrap(err, "failed to read response body"))
+	}
+
+	if c.maxResponseSize > 0 && int64(len(body)) > c.maxResponseSize {
+		return nil, c.tooLarge(res)
+	}
+
+	encoding, compressedSize := res.Header.Get("Content-Encoding"), int64(len(body))
+	decoded, err := c.decompress(res, body)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read response body")
+		return nil, err
+	}
+
+	response := &Response{req: req, res: res, body: decoded, redirects: chain, duration: time.Since(start)}
+	if res.Uncompressed && encoding != "" {
+		response.encoding, response.compressedSize = encoding, compressedSize
+	}
+
+	return response, nil
+}
+
+// untimedClient returns a copy of the http client without the overall timeout
+func (c *Client) untimedClient() *http.Client {
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	if c.redirects != nil {
+		httpClient.CheckRedirect = c.checkRedirect(nil)
+	}
+
+	return &httpClient
+}
+
+// streamingClient returns the http client for streams, who are read for longer than a single
+// request may take. A recorder only stores whole bodies, so streams go around it.
+func (c *Client) streamingClient() *http.Client {
+	httpClient := c.untimedClient()
+	if recording, ok := httpClient.Transport.(*recordingTransport); ok {
+		httpClient.Transport = recording.transport
 	}
 
-	return &Response{res, body}, nil
+	return httpClient
 }
@@ -2,10 +2,13 @@ package gohttpclient
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -50,6 +53,11 @@ func (s *TestClientSuite) Test_Request_WhenRequestIsInvalid_ShouldReturnError()
 			baseUrl: baseUrl,
 			method:  client.Get,
 		},
+		{
+			name:    "HEAD",
+			baseUrl: baseUrl,
+			method:  client.Head,
+		},
 		{
 			name:    "POST",
 			baseUrl: baseUrl,
@@ -70,11 +78,6 @@ func (s *TestClientSuite) Test_Request_WhenRequestIsInvalid_ShouldReturnError()
 			baseUrl: baseUrl,
 			method:  client.Delete,
 		},
-		{
-			name:    "CONNECT",
-			baseUrl: baseUrl,
-			method:  client.Connect,
-		},
 		{
 			name:    "OPTIONS",
 			baseUrl: baseUrl,
@@ -129,11 +132,6 @@ func (s *TestClientSuite) Test_Request_WhenDoReturnsAnError_ShouldReturnError()
 			baseUrl: baseUrlWithInvalidSchema,
 			method:  client.Delete,
 		},
-		{
-			name:    "CONNECT",
-			baseUrl: baseUrlWithInvalidSchema,
-			method:  client.Connect,
-		},
 		{
 			name:    "OPTIONS",
 			baseUrl: baseUrlWithInvalidSchema,
@@ -193,11 +191,6 @@ func (s *TestClientSuite) Test_Request_WhenBodyReturnsError_ShouldReturnError()
 			baseUrl: svc.URL,
 			method:  client.Delete,
 		},
-		{
-			name:    "CONNECT",
-			baseUrl: svc.URL,
-			method:  client.Connect,
-		},
 		{
 			name:    "OPTIONS",
 			baseUrl: svc.URL,
@@ -236,6 +229,11 @@ func (s *TestClientSuite) Test_Request_ShouldRunSuccesfully() {
 			baseUrl: svc.URL,
 			method:  client.Get,
 		},
+		{
+			name:    "HEAD",
+			baseUrl: svc.URL,
+			method:  client.Head,
+		},
 		{
 			name:    "POST",
 			baseUrl: svc.URL,
@@ -256,11 +254,6 @@ func (s *TestClientSuite) Test_Request_ShouldRunSuccesfully() {
 			baseUrl: svc.URL,
 			method:  client.Delete,
 		},
-		{
-			name:    "CONNECT",
-			baseUrl: svc.URL,
-			method:  client.Connect,
-		},
 		{
 			name:    "OPTIONS",
 			baseUrl: svc.URL,
@@ -301,6 +294,12 @@ func (s *TestClientSuite) Test_Request_WithOptions_ShouldRunSuccesfully() {
 			method:  client.Get,
 			options: []Option{WithHeader("key", "value"), WithQuery("key", "value")},
 		},
+		{
+			name:    "HEAD",
+			baseUrl: svc.URL,
+			method:  client.Head,
+			options: []Option{WithHeader("key", "value"), WithQuery("key", "value")},
+		},
 		{
 			name:    "POST",
 			baseUrl: svc.URL,
@@ -325,12 +324,6 @@ func (s *TestClientSuite) Test_Request_WithOptions_ShouldRunSuccesfully() {
 			method:  client.Delete,
 			options: []Option{WithHeader("key", "value"), WithQuery("key", "value")},
 		},
-		{
-			name:    "CONNECT",
-			baseUrl: svc.URL,
-			method:  client.Connect,
-			options: []Option{WithHeader("key", "value"), WithQuery("key", "value")},
-		},
 		{
 			name:    "OPTIONS",
 			baseUrl: svc.URL,
@@ -393,3 +386,130 @@ func (s *TestClientSuite) Test_PrepareRequest_ShouldRunSuccesfully() {
 	s.NoError(err)
 	s.Equal(body, requestBody)
 }
+
+func (s *TestClientSuite) Test_Do_ShouldSendCustomMethods() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write([]byte(r.Method + " " + string(body)))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	propfind, propfindErr := client.Do(s.ctx, "PROPFIND", "/", WithBody([]byte("<propfind/>")))
+	purge, purgeErr := client.Do(s.ctx, "PURGE", "/")
+
+	// Assert
+	s.NoError(propfindErr)
+	s.NoError(purgeErr)
+	s.Equal("PROPFIND <propfind/>", string(propfind.Body()))
+	s.Equal("PURGE ", string(purge.Body()))
+}
+
+func (s *TestClientSuite) Test_Get_WhenBodyIsGiven_ShouldSendBody() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	requests := map[string]func(ctx context.Context, endpoint string, opts ...Option) (*Response, error){
+		"GET":     client.Get,
+		"OPTIONS": client.Options,
+		"TRACE":   client.Trace,
+	}
+
+	for name, method := range requests {
+		s.Suite.Run(name, func() {
+			// Act
+			response, err := method(s.ctx, "/", WithBody([]byte("search")))
+
+			// Assert
+			s.NoError(err)
+			s.Equal("search", string(response.Body()))
+		})
+	}
+}
+
+func (s *TestClientSuite) Test_Head_ShouldReturnEmptyBody() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1024")
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithMaxResponseSize(16))
+
+	// Act
+	response, err := client.Head(s.ctx, "/")
+
+	// Assert
+	s.NoError(err)
+	s.Empty(response.Body())
+	s.Equal(int64(1024), response.Get().ContentLength)
+}
+
+func (s *TestClientSuite) Test_Send_ShouldSendPreparedRequest() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write([]byte(r.Header.Get("key") + " " + r.Header.Get("X-Trace") + " " + string(body)))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	req, err := client.PrepareRequest(s.ctx, http.MethodPost, "/", WithHeader("key", "value"))
+	s.Require().NoError(err)
+
+	req.Header.Set("X-Trace", "1")
+	req.Body = ioutil.NopCloser(strings.NewReader("custom"))
+
+	// Act
+	response, err := client.Send(req)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("value 1 custom", string(response.Body()))
+	s.Empty(req.Header.Get("Accept-Encoding"))
+}
+
+func (s *TestClientSuite) Test_Send_ShouldApplyClientTimeout() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithTimeout(10*time.Millisecond))
+	req, _ := http.NewRequest(http.MethodGet, svc.URL, nil)
+
+	// Act
+	response, err := client.Send(req)
+
+	// Assert
+	s.Nil(response)
+
+	var timeoutErr *TimeoutError
+	s.True(errors.As(err, &timeoutErr))
+}
+
+func (s *TestClientSuite) Test_Send_WhenRequestIsInvalid_ShouldReturnError() {
+	// Arrange
+	client := New("http://localhost:8080")
+	relative, _ := http.NewRequest(http.MethodGet, "/posts", nil)
+
+	for name, req := range map[string]*http.Request{"nil": nil, "relative": relative} {
+		s.Suite.Run(name, func() {
+			// Act
+			response, err := client.Send(req)
+
+			// Assert
+			s.Nil(response)
+			s.Error(err)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package gohttpclient
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Codec encodes request bodies and decodes response bodies of a media type
+	Codec interface {
+		Marshal(v any) ([]byte, error)
+		Unmarshal(data []byte, v any) error
+	}
+
+	jsonCodec struct{}
+	xmlCodec  struct{}
+	yamlCodec struct{}
+	formCodec struct{}
+)
+
+const (
+	MediaTypeJSON = "application/json"
+	MediaTypeXML  = "application/xml"
+	MediaTypeYAML = "application/yaml"
+	MediaTypeForm = "application/x-www-form-urlencoded"
+)
+
+var (
+	ErrNoCodec = errors.New("no codec registered for media type")
+
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		MediaTypeJSON:        jsonCodec{},
+		MediaTypeXML:         xmlCodec{},
+		"text/xml":           xmlCodec{},
+		MediaTypeYAML:        yamlCodec{},
+		"application/x-yaml": yamlCodec{},
+		"text/yaml":          yamlCodec{},
+		MediaTypeForm:        formCodec{},
+
+		MediaTypeProtobuf:                 protoCodec{},
+		"application/protobuf":            protoCodec{},
+		"application/vnd.google.protobuf": protoCodec{},
+	}
+
+	// suffixes maps structured syntax suffixes, as in application/vnd.api+json, to a media type
+	suffixes = map[string]string{
+		"+json": MediaTypeJSON,
+		"+xml":  MediaTypeXML,
+		"+yaml": MediaTypeYAML,
+	}
+)
+
+// RegisterCodec makes codec encode and decode bodies of mediaType, it replaces any codec
+// registered for the same media type
+func RegisterCodec(mediaType string, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	codecs[strings.ToLower(mediaType)] = codec
+}
+
+// CodecFor returns the codec of a media type or a Content-Type header value. Vendor types
+// without a codec of their own fall back to the codec of their structured syntax suffix.
+func CodecFor(contentType string) (Codec, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.Wrapf(ErrNoCodec, "%q", contentType)
+	}
+
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	if codec, ok := codecs[mediaType]; ok {
+		return codec, nil
+	}
+
+	for suffix, base := range suffixes {
+		if strings.HasSuffix(mediaType, suffix) {
+			return codecs[base], nil
+		}
+	}
+
+	return nil, errors.Wrapf(ErrNoCodec, "%q", mediaType)
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// Marshal func encodes v with an XML declaration
+func (xmlCodec) Marshal(v any) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func (xmlCodec) Unmarshal(data []byte, v any) error { return decodeXML(data, "", v) }
+
+func (xmlCodec) unmarshalCharset(data []byte, charset string, v any) error {
+	return decodeXML(data, charset, v)
+}
+
+func (yamlCodec) Marshal(v any) ([]byte, error)      { return yaml.Marshal(v) }
+func (yamlCodec) Unmarshal(data []byte, v any) error { return yaml.Unmarshal(data, v) }
+
+// Marshal func encodes url.Values, map[string]string and map[string][]string
+func (formCodec) Marshal(v any) ([]byte, error) {
+	switch values := v.(type) {
+	case url.Values:
+		return []byte(values.Encode()), nil
+	case map[string][]string:
+		return []byte(url.Values(values).Encode()), nil
+	case map[string]string:
+		form := make(url.Values, len(values))
+		for key, value := range values {
+			form.Set(key, value)
+		}
+		return []byte(form.Encode()), nil
+	}
+
+	return nil, errors.Errorf("form codec can not encode %T", v)
+}
+
+// Unmarshal func decodes into *url.Values, *map[string]string and *map[string][]string,
+// a map[string]string keeps the first value of a key
+func (formCodec) Unmarshal(data []byte, v any) error {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	switch target := v.(type) {
+	case *url.Values:
+		*target = values
+	case *map[string][]string:
+		*target = values
+	case *map[string]string:
+		*target = make(map[string]string, len(values))
+		for key := range values {
+			(*target)[key] = values.Get(key)
+		}
+	default:
+		return errors.Errorf("form codec can not decode into %T", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package gohttpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestCodecSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+type (
+	codecPost struct {
+		Title string `json:"title" xml:"title" yaml:"title"`
+	}
+
+	// reverseCodec stands in for a vendor codec, it stores the title reversed
+	reverseCodec struct{}
+)
+
+func (reverseCodec) Marshal(v any) ([]byte, error) {
+	return []byte(reverse(v.(codecPost).Title)), nil
+}
+
+func (reverseCodec) Unmarshal(data []byte, v any) error {
+	v.(*codecPost).Title = reverse(string(data))
+	return nil
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func TestCodec(t *testing.T) {
+	suite.Run(t, new(TestCodecSuite))
+}
+
+func (s *TestCodecSuite) SetupSuite() {
+	s.ctx = context.Background()
+	RegisterCodec("application/vnd.reverse", reverseCodec{})
+}
+
+func (s *TestCodecSuite) Test_Decode_ShouldPickCodecByContentType() {
+	// Arrange
+	cases := map[string]string{
+		"application/json; charset=utf-8":   `{"title":"decoded"}`,
+		"application/problem+json":          `{"title":"decoded"}`,
+		"application/xml":                   `<post><title>decoded</title></post>`,
+		"application/soap+xml":              `<post><title>decoded</title></post>`,
+		"application/yaml":                  "title: decoded\n",
+		"application/x-www-form-urlencoded": "title=decoded",
+		"application/vnd.reverse":           "dedoced",
+	}
+
+	for contentType, body := range cases {
+		s.Suite.Run(contentType, func() {
+			res := &http.Response{Header: http.Header{"Content-Type": []string{contentType}}}
+			resp := Response{res: res, body: []byte(body)}
+
+			// Act
+			var post codecPost
+			var err error
+			if contentType == MediaTypeForm {
+				var form map[string]string
+				err = resp.Decode(&form)
+				post.Title = form["title"]
+			} else {
+				err = resp.Decode(&post)
+			}
+
+			// Assert
+			s.NoError(err)
+			s.Equal("decoded", post.Title)
+		})
+	}
+}
+
+func (s *TestCodecSuite) Test_Decode_WhenMediaTypeIsUnknown_ShouldReturnError() {
+	// Arrange
+	res := &http.Response{Header: http.Header{"Content-Type": []string{"application/octet-stream"}}}
+	resp := Response{res: res, body: []byte("raw")}
+
+	// Act
+	var post codecPost
+	err := resp.Decode(&post)
+
+	// Assert
+	s.True(errors.Is(err, ErrNoCodec))
+}
+
+func (s *TestCodecSuite) Test_Decode_WhenYAMLIsMalformed_ShouldReturnError() {
+	// Arrange
+	res := &http.Response{Header: http.Header{"Content-Type": []string{MediaTypeYAML}}}
+	resp := Response{res: res, body: []byte("0: [:!00 \xef")}
+
+	// Act
+	var post map[string]any
+	var err error
+	decode := func() { err = resp.Decode(&post) }
+
+	// Assert
+	s.NotPanics(decode)
+	s.Error(err)
+}
+
+func (s *TestCodecSuite) Test_Unmarshal_WhenTargetIsNotPointer_ShouldReturnError() {
+	// Arrange
+	resp := Response{res: &http.Response{}, body: []byte(`{"title":"decoded"}`)}
+
+	// Act
+	err := resp.Unmarshal(codecPost{})
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *TestCodecSuite) Test_WithEncodedBody_ShouldUseRegisteredCodec() {
+	// Arrange
+	received := make(chan string, 1)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- fmt.Sprintf("%s %s", r.Header.Get("Content-Type"), body)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	cases := map[string]struct {
+		v        any
+		expected string
+	}{
+		MediaTypeYAML:             {v: codecPost{Title: "encoded"}, expected: "application/yaml title: encoded\n"},
+		MediaTypeForm:             {v: url.Values{"title": {"encoded"}}, expected: "application/x-www-form-urlencoded title=encoded"},
+		"application/vnd.reverse": {v: codecPost{Title: "encoded"}, expected: "application/vnd.reverse dedocne"},
+	}
+
+	for mediaType, tc := range cases {
+		s.Suite.Run(mediaType, func() {
+			// Act
+			_, err := client.Post(s.ctx, "/", WithEncodedBody(mediaType, tc.v))
+
+			// Assert
+			s.NoError(err)
+			s.Equal(tc.expected, <-received)
+		})
+	}
+}
+
+func (s *TestCodecSuite) Test_WithEncodedBody_WhenMediaTypeIsUnknown_ShouldReturnError() {
+	// Arrange
+	client := New("http://localhost:8080")
+
+	// Act
+	response, err := client.Post(s.ctx, "/", WithEncodedBody("application/octet-stream", codecPost{}))
+
+	// Assert
+	s.Nil(response)
+	s.True(errors.Is(err, ErrNoCodec))
+}
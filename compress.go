@@ -0,0 +1,201 @@
+package gohttpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+type (
+	autoCompression struct {
+		threshold int
+		encoding  string
+	}
+)
+
+const (
+	DEFAULT_MAX_DECOMPRESSED_SIZE = 64 << 20
+
+	// acceptEncoding lists every encoding decompress understands
+	acceptEncoding = "gzip, deflate, br, zstd"
+)
+
+var (
+	ErrDecompressedTooLarge = errors.New("decompressed response body exceeds the maximum size")
+)
+
+// decompressor returns a reader who decodes body for a single content coding, limit bounds the
+// memory a zstd frame may ask for through its window size
+func decompressor(encoding string, body []byte, limit int64) (io.ReadCloser, error) {
+	switch encoding {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		// deflate is meant to be zlib wrapped, yet some servers send a raw stream
+		if zr, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+			return zr, nil
+		}
+		return flate.NewReader(bytes.NewReader(body)), nil
+	case "br":
+		return ioutil.NopCloser(brotli.NewReader(bytes.NewReader(body))), nil
+	case "zstd":
+		decoder, err := zstd.NewReader(bytes.NewReader(body), zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(limit)))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+
+	return nil, errors.Errorf("unsupported content encoding %q", encoding)
+}
+
+func isSupportedEncoding(encoding string) bool {
+	switch encoding {
+	case "gzip", "x-gzip", "deflate", "br", "zstd":
+		return true
+	}
+
+	return false
+}
+
+// contentEncodings returns the codings of the header in the order they were applied,
+// identity is left out
+func contentEncodings(header http.Header) []string {
+	var encodings []string
+	for _, value := range header.Values("Content-Encoding") {
+		for _, encoding := range strings.Split(value, ",") {
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+			if encoding != "" && encoding != "identity" {
+				encodings = append(encodings, encoding)
+			}
+		}
+	}
+
+	return encodings
+}
+
+// decompress decodes body by every coding of the response, last applied first. The response is
+// changed as if the body had been sent uncompressed, as http.Transport does for gzip.
+func (c *Client) decompress(res *http.Response, body []byte) ([]byte, error) {
+	limit := c.maxDecompressedSize
+	if limit == 0 {
+		limit = DEFAULT_MAX_DECOMPRESSED_SIZE
+	}
+
+	body, decoded, err := decodeContent(res.Header, body, limit)
+	if err != nil || !decoded {
+		return body, err
+	}
+
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return body, nil
+}
+
+// decodeContent decodes body by every coding of header, last applied first. A body in an unknown
+// coding is handed over as it came, decoded reports whether body was changed.
+func decodeContent(header http.Header, body []byte, limit int64) ([]byte, bool, error) {
+	encodings := contentEncodings(header)
+	if len(encodings) == 0 || len(body) == 0 {
+		return body, false, nil
+	}
+
+	for _, encoding := range encodings {
+		if !isSupportedEncoding(encoding) {
+			return body, false, nil
+		}
+	}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		reader, err := decompressor(encodings[i], body, limit)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to decompress response body")
+		}
+
+		decoded, err := ioutil.ReadAll(io.LimitReader(reader, limit+1))
+		reader.Close()
+		if errors.Is(err, zstd.ErrWindowSizeExceeded) || errors.Is(err, zstd.ErrDecoderSizeExceeded) {
+			return nil, false, ErrDecompressedTooLarge
+		}
+
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to decompress response body")
+		}
+
+		if int64(len(decoded)) > limit {
+			return nil, false, ErrDecompressedTooLarge
+		}
+
+		body = decoded
+	}
+
+	return body, true, nil
+}
+
+// compress encodes body with gzip or zstd
+func compress(encoding string, body []byte) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "zstd":
+		encoder, err := zstd.NewWriter(&buf, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		w = encoder
+	default:
+		return nil, errors.Errorf("unsupported request body encoding %q", encoding)
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// compressBody compresses the body of a request client when WithCompressedBody asked for it,
+// or when it is larger than the threshold of WithAutoCompression
+func (c *Client) compressBody() {
+	encoding := c.bodyEncoding
+	if _, ok := c.headers["Content-Encoding"]; ok && encoding == "" {
+		return
+	}
+
+	if encoding == "" && c.autoCompression.threshold > 0 && len(c.body) > c.autoCompression.threshold {
+		encoding = c.autoCompression.encoding
+	}
+
+	if encoding == "" || c.body == nil {
+		return
+	}
+
+	body, err := compress(encoding, c.body)
+	if err != nil {
+		c.err = errors.Wrap(err, "failed to compress request body")
+		return
+	}
+
+	c.body = body
+	WithHeader("Content-Encoding", encoding)(c)
+}
@@ -0,0 +1,281 @@
+package gohttpclient
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestCompressSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestCompress(t *testing.T) {
+	suite.Run(t, new(TestCompressSuite))
+}
+
+func (s *TestCompressSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestCompressSuite) compress(encoding string, data []byte) []byte {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw deflate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	case "zstd":
+		w, _ = zstd.NewWriter(&buf)
+	}
+
+	_, err := w.Write(data)
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	return buf.Bytes()
+}
+
+func (s *TestCompressSuite) Test_Response_ShouldBeDecompressed() {
+	// Arrange
+	content := []byte(strings.Repeat(`{"title":"compressed"}`, 100))
+	cases := map[string]string{"gzip": "gzip", "deflate": "deflate", "raw deflate": "deflate", "br": "br", "zstd": "zstd"}
+
+	for name, encoding := range cases {
+		s.Suite.Run(name, func() {
+			compressed := s.compress(name, content)
+			accepted := make(chan string, 1)
+			svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accepted <- r.Header.Get("Accept-Encoding")
+				w.Header().Set("Content-Encoding", encoding)
+				w.Write(compressed)
+			}))
+			defer svc.Close()
+
+			client := New(svc.URL)
+
+			// Act
+			response, err := client.Get(s.ctx, "/")
+
+			// Assert
+			s.NoError(err)
+			s.Equal("gzip, deflate, br, zstd", <-accepted)
+			s.Equal(content, response.Body())
+			s.Equal(encoding, response.ContentEncoding())
+			s.Equal(int64(len(compressed)), response.CompressedSize())
+			s.Empty(response.Headers().Get("Content-Encoding"))
+		})
+	}
+}
+
+func (s *TestCompressSuite) Test_Response_WhenAcceptEncodingIsSet_ShouldStillBeDecompressed() {
+	// Arrange
+	compressed := s.compress("gzip", []byte("hello"))
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHeader("Accept-Encoding", "gzip"))
+
+	// Assert
+	s.NoError(err)
+	s.Equal("hello", string(response.Body()))
+}
+
+func (s *TestCompressSuite) Test_Request_WhenRangeIsSet_ShouldNotAskForEncoding() {
+	// Arrange
+	encodings := make(chan string, 1)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings <- r.Header.Get("Accept-Encoding")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("hel"))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHeader("Range", "bytes=0-2"))
+
+	// Assert
+	s.NoError(err)
+	s.Equal("hel", string(response.Body()))
+	s.Empty(<-encodings)
+}
+
+func (s *TestCompressSuite) Test_Response_WhenNotCompressed_ShouldReportBodySize() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("plain"))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(err)
+	s.Empty(response.ContentEncoding())
+	s.Equal(int64(5), response.CompressedSize())
+}
+
+func (s *TestCompressSuite) Test_WithMaxDecompressedSize_ShouldRejectZipBombs() {
+	// Arrange
+	compressed := s.compress("gzip", make([]byte, 1<<20))
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithMaxDecompressedSize(1<<10))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.True(errors.Is(err, ErrDecompressedTooLarge))
+}
+
+func (s *TestCompressSuite) Test_Response_WhenZstdWindowIsTooLarge_ShouldNotAllocateIt() {
+	// Arrange
+	// a frame with a single raw block of one byte, whose header asks for a 512MB window
+	frame := []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00, 19 << 3, 0x09, 0x00, 0x00, 'x'}
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "zstd")
+		w.Write(frame)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	response, err := client.Get(s.ctx, "/")
+	runtime.ReadMemStats(&after)
+
+	// Assert
+	s.Nil(response)
+	s.True(errors.Is(err, ErrDecompressedTooLarge))
+	s.Less(after.TotalAlloc-before.TotalAlloc, uint64(DEFAULT_MAX_DECOMPRESSED_SIZE))
+}
+
+func (s *TestCompressSuite) Test_Response_WhenEncodingIsUnknown_ShouldReturnBodyAsIs() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "compress")
+		w.Write([]byte("lzw"))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(err)
+	s.Equal("lzw", string(response.Body()))
+	s.Equal("compress", response.Headers().Get("Content-Encoding"))
+}
+
+func (s *TestCompressSuite) Test_WithCompressedBody_ShouldCompressRequestBody() {
+	// Arrange
+	type received struct {
+		encoding string
+		body     []byte
+	}
+
+	bodies := make(chan received, 1)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- received{encoding: r.Header.Get("Content-Encoding"), body: body}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	for _, encoding := range []string{"gzip", "zstd"} {
+		s.Suite.Run(encoding, func() {
+			// Act
+			_, err := client.Post(s.ctx, "/", WithCompressedBody(encoding), WithJSONBody(map[string]string{"title": "compressed"}))
+
+			// Assert
+			s.NoError(err)
+			r := <-bodies
+			s.Equal(encoding, r.encoding)
+
+			reader, err := decompressor(encoding, r.body, DEFAULT_MAX_DECOMPRESSED_SIZE)
+			s.Require().NoError(err)
+			body, _ := ioutil.ReadAll(reader)
+			s.JSONEq(`{"title":"compressed"}`, string(body))
+		})
+	}
+}
+
+func (s *TestCompressSuite) Test_WithAutoCompression_ShouldCompressLargeBodies() {
+	// Arrange
+	encodings := make(chan string, 1)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings <- r.Header.Get("Content-Encoding")
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithAutoCompression(16, "gzip"))
+
+	// Act
+	_, smallErr := client.Post(s.ctx, "/", WithBody([]byte("small")))
+	small := <-encodings
+	_, largeErr := client.Post(s.ctx, "/", WithBody([]byte(strings.Repeat("large", 10))))
+	large := <-encodings
+
+	// Assert
+	s.NoError(smallErr)
+	s.NoError(largeErr)
+	s.Empty(small)
+	s.Equal("gzip", large)
+	s.Nil(client.headers)
+}
+
+func (s *TestCompressSuite) Test_WithCompressedBody_WhenEncodingIsUnsupported_ShouldReturnError() {
+	// Arrange
+	client := New("http://localhost:8080")
+
+	// Act
+	response, err := client.Post(s.ctx, "/", WithBody([]byte("body")), WithCompressedBody("lzw"))
+
+	// Assert
+	s.Nil(response)
+	s.EqualError(err, `failed to compress request body: unsupported request body encoding "lzw"`)
+}
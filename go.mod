@@ -2,12 +2,17 @@ module github.com/bozd4g/go-http-client
 
 go 1.19
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/andybalholm/brotli v1.1.1
+	github.com/klauspost/compress v1.15.15
+	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.6.1
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20231009173412-8bfb1ae86b6c
+	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/objx v0.1.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
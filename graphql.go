@@ -0,0 +1,191 @@
+package gohttpclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// GraphQLError is a single entry of the errors array of a GraphQL response
+	GraphQLError struct {
+		Message    string            `json:"message"`
+		Path       []any             `json:"path,omitempty"`
+		Locations  []GraphQLLocation `json:"locations,omitempty"`
+		Extensions map[string]any    `json:"extensions,omitempty"`
+	}
+
+	GraphQLLocation struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	}
+
+	// GraphQLErrors is returned by GraphQL when the response has errors, data is decoded regardless
+	GraphQLErrors []GraphQLError
+
+	GraphQLOption func(c *graphQLConfig)
+
+	graphQLConfig struct {
+		operationName string
+		persisted     bool
+		opts          []Option
+	}
+
+	graphQLRequest struct {
+		Query         string         `json:"query,omitempty"`
+		Variables     map[string]any `json:"variables,omitempty"`
+		OperationName string         `json:"operationName,omitempty"`
+		Extensions    map[string]any `json:"extensions,omitempty"`
+	}
+
+	graphQLResponse struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+)
+
+const (
+	graphQLAccept = "application/graphql-response+json, application/json"
+)
+
+// WithOperationName selects the operation to run when the query document has several
+func WithOperationName(name string) GraphQLOption {
+	return func(c *graphQLConfig) {
+		c.operationName = name
+	}
+}
+
+// WithPersistedQuery sends the sha256 hash of the query instead of the query itself (automatic persisted
+// queries), the query is sent again when the server does not know the hash or does not support them
+func WithPersistedQuery() GraphQLOption {
+	return func(c *graphQLConfig) {
+		c.persisted = true
+	}
+}
+
+// WithGraphQLOptions adds request options who are sent with every GraphQL request
+func WithGraphQLOptions(opts ...Option) GraphQLOption {
+	return func(c *graphQLConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+func (e GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	path := make([]string, len(e.Path))
+	for i, segment := range e.Path {
+		path[i] = fmt.Sprint(segment)
+	}
+
+	return fmt.Sprintf("%s (path %s)", e.Message, strings.Join(path, "."))
+}
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// Code func returns extensions.code of the error, empty when there is none
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// GraphQL func posts query and variables to endpoint and decodes the data of the response into out.
+// When the response has errors, they are returned as GraphQLErrors after the data was decoded.
+func GraphQL(ctx context.Context, client *Client, endpoint, query string, variables map[string]any, out any, opts ...GraphQLOption) error {
+	var config graphQLConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	req := graphQLRequest{Query: query, Variables: variables, OperationName: config.operationName}
+	if !config.persisted {
+		return postGraphQL(ctx, client, endpoint, req, out, config.opts)
+	}
+
+	sum := sha256.Sum256([]byte(query))
+	req.Query = ""
+	req.Extensions = map[string]any{
+		"persistedQuery": map[string]any{"version": 1, "sha256Hash": hex.EncodeToString(sum[:])},
+	}
+
+	err := postGraphQL(ctx, client, endpoint, req, out, config.opts)
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) {
+		return err
+	}
+
+	switch {
+	case gqlErrs.has("PERSISTED_QUERY_NOT_FOUND", "PersistedQueryNotFound"):
+		// register the query under its hash
+		req.Query = query
+	case gqlErrs.has("PERSISTED_QUERY_NOT_SUPPORTED", "PersistedQueryNotSupported"):
+		req.Query, req.Extensions = query, nil
+	default:
+		return err
+	}
+
+	return postGraphQL(ctx, client, endpoint, req, out, config.opts)
+}
+
+func postGraphQL(ctx context.Context, client *Client, endpoint string, req graphQLRequest, out any, opts []Option) error {
+	opts = append([]Option{WithHeader("Accept", graphQLAccept), WithJSONBody(req)}, opts...)
+
+	res, err := client.Post(ctx, endpoint, opts...)
+	if err != nil {
+		return err
+	}
+
+	var body graphQLResponse
+	err = json.Unmarshal(res.Body(), &body)
+	if err == nil && body.Data == nil && body.Errors == nil {
+		err = errors.New("neither data nor errors found")
+	}
+
+	if err != nil {
+		if !res.Ok() {
+			return errors.Errorf("graphql request failed with status code %d", res.Status())
+		}
+
+		return errors.Wrap(err, "failed to decode graphql response")
+	}
+
+	if out != nil && len(body.Data) > 0 && string(body.Data) != "null" {
+		if err := json.Unmarshal(body.Data, out); err != nil {
+			return errors.Wrap(err, "failed to decode graphql data")
+		}
+	}
+
+	if len(body.Errors) > 0 {
+		return body.Errors
+	}
+
+	return nil
+}
+
+// has reports whether any error has one of the codes as extensions.code or as message
+func (e GraphQLErrors) has(codes ...string) bool {
+	for _, err := range e {
+		for _, code := range codes {
+			if err.Code() == code || err.Message == code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package gohttpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestGraphQLSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+type graphQLPost struct {
+	Post struct {
+		Title string `json:"title"`
+	} `json:"post"`
+}
+
+func TestGraphQL(t *testing.T) {
+	suite.Run(t, new(TestGraphQLSuite))
+}
+
+func (s *TestGraphQLSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+// newGraphQLServer records every request and answers with respond
+func newGraphQLServer(requests chan<- graphQLRequest, respond func(req graphQLRequest) string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests <- req
+
+		w.Header().Set("Content-Type", "application/graphql-response+json")
+		w.Write([]byte(respond(req)))
+	}))
+}
+
+func (s *TestGraphQLSuite) Test_GraphQL_ShouldDecodeData() {
+	// Arrange
+	requests := make(chan graphQLRequest, 1)
+	svc := newGraphQLServer(requests, func(req graphQLRequest) string {
+		return `{"data":{"post":{"title":"hello"}}}`
+	})
+	defer svc.Close()
+
+	client := New(svc.URL)
+	query := `query GetPost($id: ID!) { post(id: $id) { title } }`
+
+	// Act
+	var out graphQLPost
+	err := GraphQL(s.ctx, client, "/graphql", query, map[string]any{"id": "1"}, &out, WithOperationName("GetPost"))
+
+	// Assert
+	s.NoError(err)
+	s.Equal("hello", out.Post.Title)
+
+	req := <-requests
+	s.Equal(query, req.Query)
+	s.Equal("GetPost", req.OperationName)
+	s.Equal(map[string]any{"id": "1"}, req.Variables)
+}
+
+func (s *TestGraphQLSuite) Test_GraphQL_WhenResponseHasErrors_ShouldReturnTypedErrors() {
+	// Arrange
+	requests := make(chan graphQLRequest, 1)
+	svc := newGraphQLServer(requests, func(req graphQLRequest) string {
+		return `{
+			"data": {"post": {"title": "partial"}},
+			"errors": [{
+				"message": "author not found",
+				"path": ["post", "author", 0],
+				"locations": [{"line": 1, "column": 20}],
+				"extensions": {"code": "NOT_FOUND"}
+			}]
+		}`
+	})
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	var out graphQLPost
+	err := GraphQL(s.ctx, client, "/graphql", `{ post { title author { name } } }`, nil, &out)
+
+	// Assert
+	var gqlErrs GraphQLErrors
+	s.Require().True(errors.As(err, &gqlErrs))
+	s.Len(gqlErrs, 1)
+	s.Equal([]any{"post", "author", float64(0)}, gqlErrs[0].Path)
+	s.Equal([]GraphQLLocation{{Line: 1, Column: 20}}, gqlErrs[0].Locations)
+	s.Equal("NOT_FOUND", gqlErrs[0].Code())
+	s.EqualError(err, "graphql: author not found (path post.author.0)")
+	s.Equal("partial", out.Post.Title)
+}
+
+func (s *TestGraphQLSuite) Test_GraphQL_WithPersistedQuery_ShouldFallBackToQuery() {
+	// Arrange
+	requests := make(chan graphQLRequest, 2)
+	svc := newGraphQLServer(requests, func(req graphQLRequest) string {
+		if req.Query == "" {
+			return `{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`
+		}
+		return `{"data":{"post":{"title":"registered"}}}`
+	})
+	defer svc.Close()
+
+	client := New(svc.URL)
+	query := `{ post { title } }`
+
+	// Act
+	var out graphQLPost
+	err := GraphQL(s.ctx, client, "/graphql", query, nil, &out, WithPersistedQuery())
+
+	// Assert
+	s.NoError(err)
+	s.Equal("registered", out.Post.Title)
+
+	hashed, registered := <-requests, <-requests
+	persisted := hashed.Extensions["persistedQuery"].(map[string]any)
+	s.Empty(hashed.Query)
+	s.Equal("a8e27ab201ee4b1a81bd237d724f9b94da1dedffc667701cd6485ff5bcc1760a", persisted["sha256Hash"])
+	s.Equal(query, registered.Query)
+	s.Equal(persisted, registered.Extensions["persistedQuery"])
+}
+
+func (s *TestGraphQLSuite) Test_GraphQL_WhenStatusIsNotOk_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	err := GraphQL(s.ctx, client, "/graphql", `{ post { title } }`, nil, nil)
+
+	// Assert
+	s.EqualError(err, "graphql request failed with status code 502")
+}
@@ -0,0 +1,257 @@
+package gohttpclient
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Link is an entry of a Link header (RFC 8288), a link with several relations is returned once per relation
+	// and a link without a relation once with an empty Rel
+	Link struct {
+		URL    string
+		Rel    string
+		Params map[string]string
+	}
+
+	// ETag is an entity tag, Value is without quotes
+	ETag struct {
+		Value string
+		Weak  bool
+	}
+
+	// CacheControl holds the directives of a Cache-Control header, the common ones are parsed into fields
+	CacheControl struct {
+		NoCache         bool
+		NoStore         bool
+		NoTransform     bool
+		MustRevalidate  bool
+		ProxyRevalidate bool
+		Public          bool
+		Private         bool
+		Immutable       bool
+
+		// Directives has every directive by its lower cased name, with the unquoted value
+		Directives map[string]string
+	}
+
+	// ContentDisposition is a parsed Content-Disposition header, Filename prefers the filename* parameter
+	ContentDisposition struct {
+		Type     string
+		Filename string
+		Params   map[string]string
+	}
+)
+
+// Links func returns the entries of the Link headers, the urls are returned as they were sent
+func (r *Response) Links() []Link {
+	var links []Link
+	for _, header := range r.Headers().Values("Link") {
+		links = append(links, parseLinkHeader(header)...)
+	}
+
+	return links
+}
+
+// RetryAfter func returns the delay of the Retry-After header, given in seconds or as a date
+func (r *Response) RetryAfter() (time.Duration, bool) {
+	value := strings.TrimSpace(r.Headers().Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if delay := time.Until(at); delay > 0 {
+		return delay, true
+	}
+
+	return 0, true
+}
+
+func (r *Response) ETag() (ETag, bool) {
+	return parseETag(r.Headers().Get("ETag"))
+}
+
+func (r *Response) CacheControl() CacheControl {
+	return parseCacheControl(r.Headers().Values("Cache-Control"))
+}
+
+func (r *Response) LastModified() (time.Time, bool) {
+	t, err := http.ParseTime(r.Headers().Get("Last-Modified"))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func (r *Response) ContentDisposition() (ContentDisposition, bool) {
+	disposition, params, err := mime.ParseMediaType(r.Headers().Get("Content-Disposition"))
+	if err != nil {
+		return ContentDisposition{}, false
+	}
+
+	return ContentDisposition{Type: disposition, Filename: params["filename"], Params: params}, true
+}
+
+// String func returns the tag as it is sent in a header
+func (e ETag) String() string {
+	if e.Weak {
+		return `W/"` + e.Value + `"`
+	}
+
+	return `"` + e.Value + `"`
+}
+
+// Seconds func returns a directive who holds a number of seconds, as max-age or stale-while-revalidate
+func (c CacheControl) Seconds(directive string) (time.Duration, bool) {
+	value, ok := c.Directives[directive]
+	if !ok {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func (c CacheControl) MaxAge() (time.Duration, bool) {
+	return c.Seconds("max-age")
+}
+
+func (c CacheControl) SMaxAge() (time.Duration, bool) {
+	return c.Seconds("s-maxage")
+}
+
+func parseETag(value string) (ETag, bool) {
+	value = strings.TrimSpace(value)
+
+	var etag ETag
+	if strings.HasPrefix(value, "W/") {
+		etag.Weak, value = true, value[2:]
+	}
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return ETag{}, false
+	}
+
+	etag.Value = value[1 : len(value)-1]
+	return etag, true
+}
+
+func parseCacheControl(values []string) CacheControl {
+	c := CacheControl{Directives: make(map[string]string)}
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+
+			c.Directives[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+		}
+	}
+
+	_, c.NoCache = c.Directives["no-cache"]
+	_, c.NoStore = c.Directives["no-store"]
+	_, c.NoTransform = c.Directives["no-transform"]
+	_, c.MustRevalidate = c.Directives["must-revalidate"]
+	_, c.ProxyRevalidate = c.Directives["proxy-revalidate"]
+	_, c.Public = c.Directives["public"]
+	_, c.Private = c.Directives["private"]
+	_, c.Immutable = c.Directives["immutable"]
+	return c
+}
+
+// parseLinkHeader returns the links of a Link header
+func parseLinkHeader(header string) []Link {
+	var links []Link
+	for _, part := range splitLinks(header) {
+		part = strings.TrimSpace(part)
+		if !strings.HasPrefix(part, "<") {
+			continue
+		}
+
+		end := strings.Index(part, ">")
+		if end < 0 {
+			continue
+		}
+
+		var rels []string
+		params := make(map[string]string)
+		for _, param := range splitParams(part[end+1:]) {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if key == "" {
+				continue
+			}
+
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			if key == "rel" {
+				rels = strings.Fields(strings.ToLower(value))
+				continue
+			}
+
+			params[key] = value
+		}
+
+		if len(rels) == 0 {
+			rels = []string{""}
+		}
+
+		for _, rel := range rels {
+			links = append(links, Link{URL: part[1:end], Rel: rel, Params: params})
+		}
+	}
+
+	return links
+}
+
+// splitLinks splits a Link header on the commas who are outside of the <> targets and quoted strings
+func splitLinks(header string) []string {
+	return splitOutside(header, ',')
+}
+
+// splitParams splits the parameters of a link on the semicolons who are outside of quoted strings
+func splitParams(params string) []string {
+	return splitOutside(params, ';')
+}
+
+func splitOutside(header string, sep rune) []string {
+	var (
+		parts          []string
+		start          int
+		inTarget, quot bool
+	)
+
+	for i, r := range header {
+		switch {
+		case r == '<' && !quot:
+			inTarget = true
+		case r == '>' && !quot:
+			inTarget = false
+		case r == '"' && !inTarget:
+			quot = !quot
+		case r == sep && !inTarget && !quot:
+			parts = append(parts, header[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, header[start:])
+}
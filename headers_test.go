@@ -0,0 +1,190 @@
+package gohttpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestHeadersSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestHeaders(t *testing.T) {
+	suite.Run(t, new(TestHeadersSuite))
+}
+
+func (s *TestHeadersSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestHeadersSuite) response(header http.Header) *Response {
+	return &Response{res: &http.Response{Header: header}}
+}
+
+func (s *TestHeadersSuite) Test_parseLinkHeader_ShouldRunSuccesfully() {
+	// Arrange
+	header := `<https://api.example.com/items?page=2&a=1,2>; rel="next prefetch", <https://api.example.com/items?page=9>; rel=last; title="a; b, c"`
+
+	// Act
+	links := parseLinkHeader(header)
+
+	// Assert
+	s.Equal([]Link{
+		{URL: "https://api.example.com/items?page=2&a=1,2", Rel: "next", Params: map[string]string{}},
+		{URL: "https://api.example.com/items?page=2&a=1,2", Rel: "prefetch", Params: map[string]string{}},
+		{URL: "https://api.example.com/items?page=9", Rel: "last", Params: map[string]string{"title": "a; b, c"}},
+	}, links)
+}
+
+func (s *TestHeadersSuite) Test_Links_ShouldReadEveryLinkHeader() {
+	// Arrange
+	resp := s.response(http.Header{"Link": []string{`</items?page=2>; rel=next`, `</items?page=1>; rel=prev`}})
+
+	// Act
+	links := resp.Links()
+
+	// Assert
+	s.Len(links, 2)
+	s.Equal("/items?page=2", links[0].URL)
+	s.Equal("prev", links[1].Rel)
+}
+
+func (s *TestHeadersSuite) Test_Links_WhenRelIsMissing_ShouldReturnLinkWithEmptyRel() {
+	// Arrange
+	resp := s.response(http.Header{"Link": []string{`<https://x/a>; title="x"`}})
+
+	// Act
+	links := resp.Links()
+
+	// Assert
+	s.Equal([]Link{{URL: "https://x/a", Params: map[string]string{"title": "x"}}}, links)
+}
+
+func (s *TestHeadersSuite) Test_RetryAfter_ShouldParseSecondsAndDates() {
+	// Arrange
+	cases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"seconds": {value: "120", expected: 2 * time.Minute, ok: true},
+		"past":    {value: "Wed, 21 Oct 2015 07:28:00 GMT", expected: 0, ok: true},
+		"invalid": {value: "soon", expected: 0, ok: false},
+		"missing": {value: "", expected: 0, ok: false},
+	}
+
+	for name, tc := range cases {
+		s.Suite.Run(name, func() {
+			resp := s.response(http.Header{"Retry-After": []string{tc.value}})
+
+			// Act
+			delay, ok := resp.RetryAfter()
+
+			// Assert
+			s.Equal(tc.ok, ok)
+			s.Equal(tc.expected, delay)
+		})
+	}
+
+	future := s.response(http.Header{"Retry-After": []string{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}})
+	delay, ok := future.RetryAfter()
+	s.True(ok)
+	s.InDelta(float64(time.Hour), float64(delay), float64(2*time.Second))
+}
+
+func (s *TestHeadersSuite) Test_ETag_ShouldParseWeakAndStrongTags() {
+	// Arrange
+	strong := s.response(http.Header{"Etag": []string{`"abc"`}})
+	weak := s.response(http.Header{"Etag": []string{`W/"abc"`}})
+	invalid := s.response(http.Header{"Etag": []string{`abc`}})
+
+	// Act
+	strongTag, strongOk := strong.ETag()
+	weakTag, weakOk := weak.ETag()
+	_, invalidOk := invalid.ETag()
+
+	// Assert
+	s.True(strongOk)
+	s.Equal(ETag{Value: "abc"}, strongTag)
+	s.True(weakOk)
+	s.Equal(ETag{Value: "abc", Weak: true}, weakTag)
+	s.Equal(`W/"abc"`, weakTag.String())
+	s.False(invalidOk)
+}
+
+func (s *TestHeadersSuite) Test_CacheControl_ShouldParseDirectives() {
+	// Arrange
+	resp := s.response(http.Header{"Cache-Control": []string{`public, max-age=60`, `s-maxage=0, no-cache="Set-Cookie"`}})
+
+	// Act
+	cc := resp.CacheControl()
+
+	// Assert
+	s.True(cc.Public)
+	s.True(cc.NoCache)
+	s.False(cc.NoStore)
+	s.Equal("Set-Cookie", cc.Directives["no-cache"])
+
+	maxAge, ok := cc.MaxAge()
+	s.True(ok)
+	s.Equal(time.Minute, maxAge)
+
+	sMaxAge, ok := cc.SMaxAge()
+	s.True(ok)
+	s.Equal(time.Duration(0), sMaxAge)
+
+	_, ok = cc.Seconds("stale-while-revalidate")
+	s.False(ok)
+}
+
+func (s *TestHeadersSuite) Test_LastModified_ShouldParseDate() {
+	// Arrange
+	resp := s.response(http.Header{"Last-Modified": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}})
+
+	// Act
+	modified, ok := resp.LastModified()
+
+	// Assert
+	s.True(ok)
+	s.Equal(time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC), modified.UTC())
+}
+
+func (s *TestHeadersSuite) Test_ContentDisposition_ShouldPreferEncodedFilename() {
+	// Arrange
+	resp := s.response(http.Header{"Content-Disposition": []string{`attachment; filename="report.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`}})
+
+	// Act
+	disposition, ok := resp.ContentDisposition()
+
+	// Assert
+	s.True(ok)
+	s.Equal("attachment", disposition.Type)
+	s.Equal("résumé.pdf", disposition.Filename)
+}
+
+func (s *TestHeadersSuite) Test_ConditionalRequest_ShouldSendValidators() {
+	// Arrange
+	etag := ETag{Value: "abc", Weak: true}
+	modified := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `W/"abc", "def"` && r.Header.Get("If-Modified-Since") == "Wed, 21 Oct 2015 07:28:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithIfNoneMatch(etag.String(), "def"), WithIfModifiedSince(modified))
+
+	// Assert
+	s.NoError(err)
+	s.Equal(http.StatusNotModified, response.Status())
+}
@@ -0,0 +1,114 @@
+package gohttpclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	hedging struct {
+		delay time.Duration
+		max   int
+	}
+
+	attempt struct {
+		res *Response
+		err error
+	}
+)
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isReplayable reports whether the body of req can be sent more than once
+func isReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// sendHedged races identical copies of req, a new copy is fired every delay until one succeeds.
+// A 5xx response counts as a failed attempt, it is only returned when every attempt has finished.
+func (c *Client) sendHedged(ctx context.Context, req *http.Request) (*Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // cancels the losers
+
+	results := make(chan attempt, c.hedging.max+1)
+	fire := func() error {
+		clone := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return errors.Wrap(err, "failed to copy request body")
+			}
+			clone.Body = body
+		}
+
+		go func() {
+			res, err := c.roundTrip(ctx, clone)
+			results <- attempt{res, err}
+		}()
+		return nil
+	}
+
+	if err := fire(); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(c.hedging.delay)
+	defer timer.Stop()
+
+	var (
+		hedges  int
+		pending = 1
+		lastRes *Response
+		lastErr error
+	)
+
+	for {
+		select {
+		case <-timer.C:
+			if hedges >= c.hedging.max {
+				continue
+			}
+
+			if err := fire(); err != nil {
+				return nil, err
+			}
+
+			hedges++
+			pending++
+			timer.Reset(c.hedging.delay)
+		case a := <-results:
+			pending--
+			if a.err == nil && a.res.Status() < http.StatusInternalServerError {
+				a.res.hedges = hedges
+				return a.res, nil
+			}
+
+			if a.err == nil {
+				lastRes = a.res
+			} else {
+				lastErr = a.err
+			}
+
+			if pending > 0 || (hedges < c.hedging.max && ctx.Err() == nil) {
+				continue
+			}
+
+			if lastRes != nil {
+				lastRes.hedges = hedges
+				return lastRes, nil
+			}
+
+			return nil, lastErr
+		}
+	}
+}
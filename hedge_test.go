@@ -0,0 +1,180 @@
+package gohttpclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestHedgeSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestHedge(t *testing.T) {
+	suite.Run(t, new(TestHedgeSuite))
+}
+
+func (s *TestHedgeSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenFirstReplicaIsSlow_ShouldReturnHedgedResponse() {
+	// Arrange
+	var hits, cancelled int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if atomic.AddInt32(&hits, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+				atomic.AddInt32(&cancelled, 1)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+
+		w.Write(append([]byte("fast:"), body...))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	start := time.Now()
+	response, err := client.Put(s.ctx, "/", WithBody([]byte("body")), WithHedging(20*time.Millisecond, 2))
+
+	// Assert
+	s.NoError(err)
+	s.Less(int64(time.Since(start)), int64(time.Second))
+	s.Equal("fast:body", string(response.Body()))
+	s.Equal(1, response.Hedges())
+	s.Eventually(func() bool { return atomic.LoadInt32(&cancelled) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenResponseIsFast_ShouldNotHedge() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHedging(time.Second, 2))
+
+	// Assert
+	s.NoError(err)
+	s.Equal(0, response.Hedges())
+	s.Equal(int32(1), atomic.LoadInt32(&hits))
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenMaxHedgesIsNegative_ShouldNotHedge() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	var response *Response
+	var err error
+	s.NotPanics(func() {
+		response, err = client.Get(s.ctx, "/", WithHedging(time.Millisecond, -2))
+	})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(0, response.Hedges())
+	s.Equal(int32(1), atomic.LoadInt32(&hits))
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenMethodIsNotIdempotent_ShouldNotHedge() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Post(s.ctx, "/", WithHedging(time.Millisecond, 3))
+
+	// Assert
+	s.NoError(err)
+	s.Equal(0, response.Hedges())
+	s.Equal(int32(1), atomic.LoadInt32(&hits))
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenEveryAttemptFails_ShouldReturnError() {
+	// Arrange
+	client := New("http://127.0.0.1:1")
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHedging(time.Millisecond, 2))
+
+	// Assert
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenHedgeFailsWithServerError_ShouldWaitForHealthyAttempt() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte("healthy"))
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHedging(10*time.Millisecond, 2))
+
+	// Assert
+	s.NoError(err)
+	s.Equal(http.StatusOK, response.Status())
+	s.Equal("healthy", string(response.Body()))
+	s.Equal(int32(3), atomic.LoadInt32(&hits))
+}
+
+func (s *TestHedgeSuite) Test_WithHedging_WhenEveryAttemptFailsWithServerError_ShouldReturnLastResponse() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHedging(time.Millisecond, 2))
+
+	// Assert
+	s.NoError(err)
+	s.Equal(http.StatusBadGateway, response.Status())
+	s.Equal(2, response.Hedges())
+	s.Equal(int32(3), atomic.LoadInt32(&hits))
+}
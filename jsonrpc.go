@@ -0,0 +1,195 @@
+package gohttpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// JSONRPC calls the JSON-RPC 2.0 methods of an endpoint over Client.Post
+	JSONRPC struct {
+		client   *Client
+		endpoint string
+		opts     []Option
+		lastID   int64
+	}
+
+	// RPCCall is a single call of a batch, Result is decoded into and Err is set after the batch returns.
+	// A notification gets no response, so neither Result nor Err are set for it.
+	RPCCall struct {
+		Method       string
+		Params       any
+		Result       any
+		Notification bool
+		Err          error
+	}
+
+	// RPCError is the error object of a JSON-RPC response
+	RPCError struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}
+
+	rpcRequest struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      *int64 `json:"id,omitempty"`
+		Method  string `json:"method"`
+		Params  any    `json:"params,omitempty"`
+	}
+
+	rpcResponse struct {
+		ID     *int64          `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+)
+
+// standard error codes of the JSON-RPC 2.0 specification
+const (
+	RPCParseError     = -32700
+	RPCInvalidRequest = -32600
+	RPCMethodNotFound = -32601
+	RPCInvalidParams  = -32602
+	RPCInternalError  = -32603
+)
+
+// NewJSONRPC func returns a JSONRPC who posts to endpoint with opts on every request
+func NewJSONRPC(client *Client, endpoint string, opts ...Option) *JSONRPC {
+	return &JSONRPC{client: client, endpoint: endpoint, opts: opts}
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// DecodeData func decodes the data member of the error into v
+func (e *RPCError) DecodeData(v any) error {
+	if len(e.Data) == 0 {
+		return errors.New("jsonrpc error has no data")
+	}
+
+	return json.Unmarshal(e.Data, v)
+}
+
+// Call func calls method with params and decodes the result into result, a nil result skips decoding.
+// An error response is returned as *RPCError.
+func (r *JSONRPC) Call(ctx context.Context, method string, params, result any) error {
+	id := r.nextID()
+	body, err := r.post(ctx, rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	var res rpcResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return errors.Wrap(err, "failed to decode jsonrpc response")
+	}
+
+	if res.ID != nil && *res.ID != id {
+		return errors.Errorf("jsonrpc response id %d does not match request id %d", *res.ID, id)
+	}
+
+	return res.decode(result)
+}
+
+// Notify func calls method with params without waiting for a result
+func (r *JSONRPC) Notify(ctx context.Context, method string, params any) error {
+	_, err := r.post(ctx, rpcRequest{JSONRPC: "2.0", Method: method, Params: params})
+	return err
+}
+
+// Batch func sends every call in a single request and matches the responses to the calls by id.
+// The returned error is about the request itself, errors of single calls are set on RPCCall.Err.
+func (r *JSONRPC) Batch(ctx context.Context, calls []*RPCCall) error {
+	reqs := make([]rpcRequest, len(calls))
+	ids := make(map[int64]*RPCCall, len(calls))
+	for i, call := range calls {
+		reqs[i] = rpcRequest{JSONRPC: "2.0", Method: call.Method, Params: call.Params}
+		if !call.Notification {
+			id := r.nextID()
+			reqs[i].ID, ids[id] = &id, call
+		}
+	}
+
+	body, err := r.post(ctx, reqs)
+	if err != nil {
+		return err
+	}
+
+	// a batch of notifications only has an empty answer
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var responses []rpcResponse
+	if err := json.Unmarshal(body, &responses); err != nil {
+		// a batch who could not be parsed at all is answered by a single error
+		var res rpcResponse
+		if json.Unmarshal(body, &res) == nil && res.Error != nil {
+			return res.Error
+		}
+
+		return errors.Wrap(err, "failed to decode jsonrpc batch response")
+	}
+
+	for _, res := range responses {
+		if res.ID == nil {
+			continue
+		}
+
+		if call, ok := ids[*res.ID]; ok {
+			call.Err = res.decode(call.Result)
+			delete(ids, *res.ID)
+		}
+	}
+
+	for id, call := range ids {
+		call.Err = errors.Errorf("no jsonrpc response for request id %d", id)
+	}
+
+	return nil
+}
+
+func (r *JSONRPC) nextID() int64 {
+	return atomic.AddInt64(&r.lastID, 1)
+}
+
+// post sends payload and returns the response body, a non-2xx response is only an error
+// when it carries no JSON-RPC answer
+func (r *JSONRPC) post(ctx context.Context, payload any) ([]byte, error) {
+	opts := append([]Option{WithHeader("Accept", MediaTypeJSON), WithJSONBody(payload)}, r.opts...)
+
+	res, err := r.client.Post(ctx, r.endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	body := bytes.TrimSpace(res.Body())
+	if !res.Ok() && !json.Valid(body) {
+		return nil, errors.Errorf("jsonrpc request failed with status code %d", res.Status())
+	}
+
+	return body, nil
+}
+
+func (res rpcResponse) decode(result any) error {
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if result == nil || len(res.Result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(res.Result, result); err != nil {
+		return errors.Wrap(err, "failed to decode jsonrpc result")
+	}
+
+	return nil
+}
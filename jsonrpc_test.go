@@ -0,0 +1,178 @@
+package gohttpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestJSONRPCSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestJSONRPC(t *testing.T) {
+	suite.Run(t, new(TestJSONRPCSuite))
+}
+
+func (s *TestJSONRPCSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+// answer returns the response of a test server to a single request, nil for notifications
+func answer(req rpcRequest) *rpcResponse {
+	if req.ID == nil {
+		return nil
+	}
+
+	switch req.Method {
+	case "add":
+		var params []int
+		raw, _ := json.Marshal(req.Params)
+		json.Unmarshal(raw, &params)
+		result, _ := json.Marshal(params[0] + params[1])
+		return &rpcResponse{ID: req.ID, Result: result}
+	default:
+		return &rpcResponse{ID: req.ID, Error: &RPCError{Code: RPCMethodNotFound, Message: "Method not found", Data: json.RawMessage(`"` + req.Method + `"`)}}
+	}
+}
+
+// newJSONRPCServer answers single and batch requests, batch answers are sent in reverse order
+func newJSONRPCServer(notified chan<- string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw json.RawMessage
+		json.NewDecoder(r.Body).Decode(&raw)
+
+		var batch []rpcRequest
+		if json.Unmarshal(raw, &batch) != nil {
+			var req rpcRequest
+			json.Unmarshal(raw, &req)
+			batch = []rpcRequest{req}
+			if res := answer(req); res != nil {
+				json.NewEncoder(w).Encode(res)
+				return
+			}
+		}
+
+		var responses []*rpcResponse
+		for i := len(batch) - 1; i >= 0; i-- {
+			if res := answer(batch[i]); res != nil {
+				responses = append(responses, res)
+			} else {
+				notified <- batch[i].Method
+			}
+		}
+
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+}
+
+func (s *TestJSONRPCSuite) Test_Call_ShouldDecodeResult() {
+	// Arrange
+	svc := newJSONRPCServer(nil)
+	defer svc.Close()
+
+	rpc := NewJSONRPC(New(svc.URL), "/rpc")
+
+	// Act
+	var sum int
+	err := rpc.Call(s.ctx, "add", []int{1, 2}, &sum)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(3, sum)
+}
+
+func (s *TestJSONRPCSuite) Test_Call_WhenMethodIsUnknown_ShouldReturnRPCError() {
+	// Arrange
+	svc := newJSONRPCServer(nil)
+	defer svc.Close()
+
+	rpc := NewJSONRPC(New(svc.URL), "/rpc")
+
+	// Act
+	err := rpc.Call(s.ctx, "subtract", []int{1, 2}, nil)
+
+	// Assert
+	var rpcErr *RPCError
+	s.Require().True(errors.As(err, &rpcErr))
+	s.Equal(RPCMethodNotFound, rpcErr.Code)
+	s.EqualError(err, "jsonrpc error -32601: Method not found")
+
+	var method string
+	s.NoError(rpcErr.DecodeData(&method))
+	s.Equal("subtract", method)
+}
+
+func (s *TestJSONRPCSuite) Test_Notify_ShouldNotExpectResponse() {
+	// Arrange
+	notified := make(chan string, 1)
+	svc := newJSONRPCServer(notified)
+	defer svc.Close()
+
+	rpc := NewJSONRPC(New(svc.URL), "/rpc")
+
+	// Act
+	err := rpc.Notify(s.ctx, "log", []string{"hello"})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("log", <-notified)
+}
+
+func (s *TestJSONRPCSuite) Test_Batch_ShouldCorrelateResponsesById() {
+	// Arrange
+	notified := make(chan string, 1)
+	svc := newJSONRPCServer(notified)
+	defer svc.Close()
+
+	rpc := NewJSONRPC(New(svc.URL), "/rpc")
+
+	var first, second int
+	calls := []*RPCCall{
+		{Method: "add", Params: []int{1, 2}, Result: &first},
+		{Method: "log", Params: []string{"batch"}, Notification: true},
+		{Method: "subtract", Params: []int{1, 2}},
+		{Method: "add", Params: []int{10, 20}, Result: &second},
+	}
+
+	// Act
+	err := rpc.Batch(s.ctx, calls)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("log", <-notified)
+	s.NoError(calls[0].Err)
+	s.Equal(3, first)
+	s.NoError(calls[1].Err)
+
+	var rpcErr *RPCError
+	s.True(errors.As(calls[2].Err, &rpcErr))
+	s.NoError(calls[3].Err)
+	s.Equal(30, second)
+}
+
+func (s *TestJSONRPCSuite) Test_Call_WhenStatusIsNotOk_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svc.Close()
+
+	rpc := NewJSONRPC(New(svc.URL), "/rpc")
+
+	// Act
+	err := rpc.Call(s.ctx, "add", []int{1, 2}, nil)
+
+	// Assert
+	s.EqualError(err, "jsonrpc request failed with status code 503")
+}
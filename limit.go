@@ -0,0 +1,41 @@
+package gohttpclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// ResponseTooLargeError is returned when a response body exceeds the maximum response size,
+	// it carries what was received before reading stopped
+	ResponseTooLargeError struct {
+		Status        int
+		Header        http.Header
+		ContentLength int64
+		Limit         int64
+	}
+)
+
+var (
+	ErrResponseTooLarge = errors.New("response body exceeds the maximum size")
+)
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s of %d bytes", ErrResponseTooLarge, e.Limit)
+}
+
+// Is func makes errors.Is(err, ErrResponseTooLarge) match
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+func (c *Client) tooLarge(res *http.Response) error {
+	return &ResponseTooLargeError{
+		Status:        res.StatusCode,
+		Header:        res.Header,
+		ContentLength: res.ContentLength,
+		Limit:         c.maxResponseSize,
+	}
+}
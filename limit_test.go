@@ -0,0 +1,92 @@
+package gohttpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestLimitSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestLimit(t *testing.T) {
+	suite.Run(t, new(TestLimitSuite))
+}
+
+func (s *TestLimitSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestLimitSuite) Test_WithMaxResponseSize_WhenContentLengthIsTooLarge_ShouldFailUpFront() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "1")
+		w.Write([]byte(strings.Repeat("a", 64)))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithMaxResponseSize(16))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.True(errors.Is(err, ErrResponseTooLarge))
+
+	var tooLarge *ResponseTooLargeError
+	s.Require().True(errors.As(err, &tooLarge))
+	s.Equal(http.StatusOK, tooLarge.Status)
+	s.Equal("1", tooLarge.Header.Get("X-Request-Id"))
+	s.Equal(int64(64), tooLarge.ContentLength)
+	s.Equal(int64(16), tooLarge.Limit)
+	s.EqualError(err, "response body exceeds the maximum size of 16 bytes")
+}
+
+func (s *TestLimitSuite) Test_WithMaxResponseSize_WhenChunkedBodyIsTooLarge_ShouldStopReading() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 8; i++ {
+			w.Write([]byte(strings.Repeat("a", 8)))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithMaxResponseSize(16))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+
+	var tooLarge *ResponseTooLargeError
+	s.Require().True(errors.As(err, &tooLarge))
+	s.Equal(int64(-1), tooLarge.ContentLength)
+}
+
+func (s *TestLimitSuite) Test_WithRequestMaxResponseSize_ShouldOverrideClientLimit() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 64)))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithMaxResponseSize(16))
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithRequestMaxResponseSize(64))
+
+	// Assert
+	s.NoError(err)
+	s.Len(response.Body(), 64)
+	s.Equal(int64(16), client.maxResponseSize)
+}
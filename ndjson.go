@@ -0,0 +1,135 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// NDJSONDecoder decodes a newline delimited json stream one line at a time, use it like a bufio.Scanner
+	NDJSONDecoder[T any] struct {
+		scanner *bufio.Scanner
+		item    T
+		line    int
+		err     error
+	}
+)
+
+const (
+	DEFAULT_MAX_LINE_SIZE = 1 << 20
+)
+
+var (
+	ErrLineTooLong = errors.New("ndjson line exceeds the maximum line size")
+)
+
+// NewNDJSONDecoder func returns a decoder who reads lines of at most maxLineSize bytes from r
+func NewNDJSONDecoder[T any](r io.Reader, maxLineSize int) *NDJSONDecoder[T] {
+	if maxLineSize <= 0 {
+		maxLineSize = DEFAULT_MAX_LINE_SIZE
+	}
+
+	initial := 4096
+	if maxLineSize < initial {
+		initial = maxLineSize
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, initial), maxLineSize)
+	return &NDJSONDecoder[T]{scanner: scanner}
+}
+
+// Next func decodes the following line, it returns false at the end of the stream or on an error
+func (d *NDJSONDecoder[T]) Next(ctx context.Context) bool {
+	if d.err != nil {
+		return false
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			d.err = err
+			return false
+		}
+
+		if !d.scanner.Scan() {
+			d.err = d.scanner.Err()
+			if errors.Is(d.err, bufio.ErrTooLong) {
+				d.err = errors.Wrapf(ErrLineTooLong, "line %d", d.line+1)
+			}
+
+			return false
+		}
+
+		d.line++
+		line := bytes.TrimSpace(d.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var item T
+		if err := json.Unmarshal(line, &item); err != nil {
+			d.err = errors.Wrapf(err, "failed to decode line %d", d.line)
+			return false
+		}
+
+		d.item = item
+		return true
+	}
+}
+
+// Item func returns the item decoded by the last Next call
+func (d *NDJSONDecoder[T]) Item() T {
+	return d.item
+}
+
+// Err func returns the error who stopped the decoder, it is nil at the end of the stream
+func (d *NDJSONDecoder[T]) Err() error {
+	return d.err
+}
+
+// StreamNDJSON func sends the request and calls fn for every line of the response body as it arrives.
+// The body is never buffered as a whole and the next line is only read after fn returns.
+// The client timeout does not apply, the stream runs until it ends, fn returns an error or ctx is cancelled.
+func StreamNDJSON[T any](ctx context.Context, client *Client, method, endpoint string, fn func(item T) error, opts ...Option) error {
+	rc := client.withOpts(opts...)
+
+	var body io.Reader
+	if rc.body != nil {
+		body = bytes.NewBuffer(rc.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rc.baseUrl+endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req, err = rc.prepareReq(req)
+	if err != nil {
+		return err
+	}
+
+	res, err := rc.streamingClient().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.Errorf("unexpected status code %d for ndjson stream", res.StatusCode)
+	}
+
+	decoder := NewNDJSONDecoder[T](res.Body, rc.maxLineSize)
+	for decoder.Next(ctx) {
+		if err := fn(decoder.Item()); err != nil {
+			return err
+		}
+	}
+
+	return decoder.Err()
+}
@@ -0,0 +1,171 @@
+package gohttpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestNDJSONSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestNDJSON(t *testing.T) {
+	suite.Run(t, new(TestNDJSONSuite))
+}
+
+func (s *TestNDJSONSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestNDJSONSuite) Test_NDJSONDecoder_ShouldDecodeEveryLine() {
+	// Arrange
+	body := "{\"id\":1}\n\n{\"id\":2}\r\n{\"id\":3}"
+	decoder := NewNDJSONDecoder[testItem](strings.NewReader(body), 0)
+
+	// Act
+	var items []testItem
+	for decoder.Next(s.ctx) {
+		items = append(items, decoder.Item())
+	}
+
+	// Assert
+	s.NoError(decoder.Err())
+	s.Equal([]testItem{{1}, {2}, {3}}, items)
+}
+
+func (s *TestNDJSONSuite) Test_NDJSONDecoder_WhenLineIsTooLong_ShouldReturnError() {
+	// Arrange
+	body := "{\"id\":1}\n{\"id\":" + strings.Repeat("1", 64) + "}\n"
+	decoder := NewNDJSONDecoder[testItem](strings.NewReader(body), 32)
+
+	// Act
+	var items []testItem
+	for decoder.Next(s.ctx) {
+		items = append(items, decoder.Item())
+	}
+
+	// Assert
+	s.Len(items, 1)
+	s.True(errors.Is(decoder.Err(), ErrLineTooLong))
+}
+
+func (s *TestNDJSONSuite) Test_NDJSONDecoder_WhenLineIsInvalid_ShouldReturnError() {
+	// Arrange
+	decoder := NewNDJSONDecoder[testItem](strings.NewReader("{\"id\":1}\n{\"id\"\n"), 0)
+
+	// Act
+	var items []testItem
+	for decoder.Next(s.ctx) {
+		items = append(items, decoder.Item())
+	}
+
+	// Assert
+	s.Len(items, 1)
+	s.EqualError(decoder.Err(), "failed to decode line 2: unexpected end of JSON input")
+}
+
+func (s *TestNDJSONSuite) Test_StreamNDJSON_ShouldCallFnForEveryLine() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("full", r.URL.Query().Get("export"))
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "{\"id\":%d}\n", i)
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	var items []testItem
+	err := StreamNDJSON(s.ctx, client, http.MethodPost, "/export", func(item testItem) error {
+		items = append(items, item)
+		return nil
+	}, WithQuery("export", "full"))
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]testItem{{1}, {2}, {3}}, items)
+}
+
+func (s *TestNDJSONSuite) Test_StreamNDJSON_WhenLineIsTooLong_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "{\"id\":%s}\n", strings.Repeat("1", 64))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	err := StreamNDJSON(s.ctx, client, http.MethodGet, "/export", func(item testItem) error {
+		return nil
+	}, WithMaxLineSize(16))
+
+	// Assert
+	s.True(errors.Is(err, ErrLineTooLong))
+}
+
+func (s *TestNDJSONSuite) Test_StreamNDJSON_WhenContextIsCancelled_ShouldStopMidStream() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 1; ; i++ {
+			select {
+			case <-r.Context().Done():
+				return
+			default:
+			}
+
+			fmt.Fprintf(w, "{\"id\":%d}\n", i)
+			w.(http.Flusher).Flush()
+			time.Sleep(time.Millisecond)
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	// Act
+	var seen int
+	err := StreamNDJSON(ctx, client, http.MethodGet, "/export", func(item testItem) error {
+		seen++
+		if seen == 5 {
+			cancel()
+		}
+		return nil
+	})
+
+	// Assert
+	s.True(errors.Is(err, context.Canceled))
+	s.Equal(5, seen)
+}
+
+func (s *TestNDJSONSuite) Test_StreamNDJSON_WhenStatusIsNotOk_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	err := StreamNDJSON(s.ctx, client, http.MethodGet, "/export", func(item testItem) error {
+		return nil
+	})
+
+	// Assert
+	s.Error(err)
+}
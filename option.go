@@ -1,8 +1,14 @@
 package gohttpclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 )
 
 type (
@@ -13,6 +19,7 @@ type (
 func WithCustomHttpClient(client *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = client
+		c.customClient = true
 	}
 }
 
@@ -27,6 +34,18 @@ func WithDefaultHeaders() ClientOption {
 	}
 }
 
+// WithDefaultXMLHeaders is the XML counterpart of WithDefaultHeaders
+func WithDefaultXMLHeaders() ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(map[string]Header)
+		}
+
+		c.headers["Content-Type"] = Header{Value: MediaTypeXML + "; charset=utf-8", IsDefault: true}
+		c.headers["Accept"] = Header{Value: MediaTypeXML, IsDefault: true}
+	}
+}
+
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
 		c.timeout = timeout
@@ -34,6 +53,48 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithRequestTimeout overrides the client timeout for a single request
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithConnectTimeout limits the time to get a connection, including the dial and the TLS handshake
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.connect = timeout
+	}
+}
+
+// WithHeaderTimeout limits the time between writing the request and receiving the response headers
+func WithHeaderTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.header = timeout
+	}
+}
+
+// WithIdleReadTimeout limits how long a read of the response body may wait for data
+func WithIdleReadTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts.idleRead = timeout
+	}
+}
+
+// WithRedirectPolicy applies policy to the redirects of every request
+func WithRedirectPolicy(policy RedirectPolicy) ClientOption {
+	return func(c *Client) {
+		c.redirects = &policy
+	}
+}
+
+// WithRequestRedirectPolicy overrides the redirect policy of the client for a single request
+func WithRequestRedirectPolicy(policy RedirectPolicy) Option {
+	return func(c *Client) {
+		c.redirects = &policy
+	}
+}
+
 func WithHeader(key, value string) Option {
 	return func(c *Client) {
 		if c.headers == nil {
@@ -44,6 +105,25 @@ func WithHeader(key, value string) Option {
 	}
 }
 
+// WithIfNoneMatch makes the request conditional on none of the entity tags matching, tags are quoted
+// when they are not yet, as the value of ETag.String() is
+func WithIfNoneMatch(etags ...string) Option {
+	quoted := make([]string, len(etags))
+	for i, etag := range etags {
+		if etag != "*" && !strings.HasSuffix(etag, `"`) {
+			etag = `"` + etag + `"`
+		}
+		quoted[i] = etag
+	}
+
+	return WithHeader("If-None-Match", strings.Join(quoted, ", "))
+}
+
+// WithIfModifiedSince makes the request conditional on the resource being modified after t
+func WithIfModifiedSince(t time.Time) Option {
+	return WithHeader("If-Modified-Since", t.UTC().Format(http.TimeFormat))
+}
+
 func WithQuery(key, value string) Option {
 	return func(c *Client) {
 		if c.query == nil {
@@ -59,3 +139,257 @@ func WithBody(body []byte) Option {
 		c.body = body
 	}
 }
+
+// WithJSONBody sends v encoded as JSON with a matching Content-Type
+func WithJSONBody(v any) Option {
+	return WithEncodedBody(MediaTypeJSON, v)
+}
+
+// WithXMLBody sends v encoded as XML, and asks for an XML response unless an Accept header was set
+func WithXMLBody(v any) Option {
+	return func(c *Client) {
+		WithEncodedBody(MediaTypeXML+"; charset=utf-8", v)(c)
+		if header, ok := c.headers["Accept"]; !ok || header.IsDefault {
+			c.headers["Accept"] = Header{Value: MediaTypeXML, IsDefault: true}
+		}
+	}
+}
+
+// WithProtoBody sends m encoded as protobuf, and asks for a protobuf response unless an Accept header was set
+func WithProtoBody(m proto.Message) Option {
+	return func(c *Client) {
+		WithEncodedBody(MediaTypeProtobuf, m)(c)
+		if header, ok := c.headers["Accept"]; !ok || header.IsDefault {
+			c.headers["Accept"] = Header{Value: MediaTypeProtobuf, IsDefault: true}
+		}
+	}
+}
+
+// WithEncodedBody sends v encoded by the codec registered for mediaType, and sets it as Content-Type
+func WithEncodedBody(mediaType string, v any) Option {
+	return func(c *Client) {
+		codec, err := CodecFor(mediaType)
+		if err != nil {
+			c.err = err
+			return
+		}
+
+		body, err := codec.Marshal(v)
+		if err != nil {
+			c.err = errors.Wrapf(err, "failed to encode %s body", mediaType)
+			return
+		}
+
+		c.body = body
+		WithHeader("Content-Type", mediaType)(c)
+	}
+}
+
+// WithCompressedBody compresses the request body with encoding, gzip or zstd, and sets Content-Encoding
+func WithCompressedBody(encoding string) Option {
+	return func(c *Client) {
+		c.bodyEncoding = encoding
+	}
+}
+
+// WithAutoCompression compresses request bodies larger than threshold bytes with encoding, gzip or zstd,
+// unless the request chose an encoding itself
+func WithAutoCompression(threshold int, encoding string) ClientOption {
+	return func(c *Client) {
+		c.autoCompression = autoCompression{threshold: threshold, encoding: encoding}
+	}
+}
+
+// WithRecorder records and replays the requests of the client with recorder. A recorder may be
+// shared by several clients, each one keeps sending through its own transport.
+// Streams opened by SSE, StreamNDJSON and WebSocket are not recorded.
+func WithRecorder(recorder *Recorder) ClientOption {
+	return func(c *Client) {
+		transport := recorder.transport
+		if transport == nil {
+			transport = c.httpClient.Transport
+		}
+
+		c.httpClient.Transport = &recordingTransport{recorder: recorder, transport: transport}
+	}
+}
+
+// WithHedging sends another identical request whenever no response arrived within delay,
+// up to maxHedges extra requests. The first successful response wins and the others are cancelled.
+// It only applies to idempotent methods, a negative maxHedges is treated as 0.
+func WithHedging(delay time.Duration, maxHedges int) Option {
+	return func(c *Client) {
+		if maxHedges < 0 {
+			maxHedges = 0
+		}
+
+		c.hedging = hedging{delay: delay, max: maxHedges}
+	}
+}
+
+// WithMaxLineSize limits the length of a single line of a streamed NDJSON body
+func WithMaxLineSize(size int) Option {
+	return func(c *Client) {
+		c.maxLineSize = size
+	}
+}
+
+// WithMaxResponseSize limits the size of a response body, larger bodies fail with ErrResponseTooLarge
+func WithMaxResponseSize(size int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseSize = size
+	}
+}
+
+// WithRequestMaxResponseSize overrides the maximum response size of the client for a single request
+func WithRequestMaxResponseSize(size int64) Option {
+	return func(c *Client) {
+		c.maxResponseSize = size
+	}
+}
+
+// WithMaxDecompressedSize limits the size a compressed response body may decode to
+func WithMaxDecompressedSize(size int64) ClientOption {
+	return func(c *Client) {
+		c.maxDecompressedSize = size
+	}
+}
+
+// WithProxy sends requests through an http, https or socks5 proxy. Credentials are taken from the
+// user info of proxyUrl. Hosts matching an entry of noProxy are reached directly.
+// It has no effect when the http client uses a custom http.RoundTripper.
+func WithProxy(proxyUrl string, noProxy ...string) ClientOption {
+	return func(c *Client) {
+		if transport := c.transport(); transport != nil {
+			transport.Proxy = proxyFunc(proxyUrl, noProxy)
+		}
+	}
+}
+
+// WithRootCAs trusts only the certificate authorities in the PEM encoded certs
+func WithRootCAs(pemCerts []byte) ClientOption {
+	return func(c *Client) {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemCerts) {
+			c.err = errors.New("no certificate found in the root CA PEM")
+			return
+		}
+
+		if config := c.tlsConfig(); config != nil {
+			config.RootCAs = pool
+		}
+	}
+}
+
+// WithClientCertificate presents the PEM encoded certificate and key for mutual TLS.
+// The files are checked on every handshake and loaded again after they change.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(c *Client) {
+		reloader, err := newCertReloader(certFile, keyFile)
+		if err != nil {
+			c.err = err
+			return
+		}
+
+		if config := c.tlsConfig(); config != nil {
+			config.GetClientCertificate = reloader.GetClientCertificate
+		}
+	}
+}
+
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) {
+		if config := c.tlsConfig(); config != nil {
+			config.MinVersion = version
+		}
+	}
+}
+
+// WithPinnedPublicKeys rejects servers whose certificate chain has none of the pinned keys.
+// Pins are base64 encoded sha256 hashes of the SubjectPublicKeyInfo, optionally prefixed by "sha256/".
+func WithPinnedPublicKeys(pins ...string) ClientOption {
+	return func(c *Client) {
+		if config := c.tlsConfig(); config != nil {
+			config.VerifyConnection = verifyPins(pins)
+		}
+	}
+}
+
+func WithMaxIdleConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		if transport := c.transport(); transport != nil {
+			transport.MaxIdleConnsPerHost = n
+		}
+	}
+}
+
+func WithIdleConnTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if transport := c.transport(); transport != nil {
+			transport.IdleConnTimeout = timeout
+		}
+	}
+}
+
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if dialer := c.dialer(); dialer != nil {
+			dialer.Timeout = timeout
+		}
+	}
+}
+
+// WithKeepAlive sets the TCP keep-alive period, a negative value disables keep-alives
+func WithKeepAlive(period time.Duration) ClientOption {
+	return func(c *Client) {
+		if dialer := c.dialer(); dialer != nil {
+			dialer.KeepAlive = period
+		}
+	}
+}
+
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if transport := c.transport(); transport != nil {
+			transport.TLSHandshakeTimeout = timeout
+		}
+	}
+}
+
+func WithResponseHeaderTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if transport := c.transport(); transport != nil {
+			transport.ResponseHeaderTimeout = timeout
+		}
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2 for TLS connections
+func WithHTTP2(enabled bool) ClientOption {
+	return func(c *Client) {
+		transport := c.transport()
+		if transport == nil {
+			return
+		}
+
+		transport.ForceAttemptHTTP2 = enabled
+		if enabled {
+			transport.TLSNextProto = nil
+			return
+		}
+
+		// a non-nil empty map is how http.Transport is told to never upgrade
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+
+		// a transport cloned after its first use already advertises h2 through ALPN
+		if config := transport.TLSClientConfig; config != nil {
+			protos := make([]string, 0, len(config.NextProtos))
+			for _, proto := range config.NextProtos {
+				if proto != "h2" {
+					protos = append(protos, proto)
+				}
+			}
+			config.NextProtos = protos
+		}
+	}
+}
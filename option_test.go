@@ -109,3 +109,70 @@ func (s *TestOptionSuite) Test_WithBody_ShouldRunSuccesfully() {
 	// Assert
 	s.Assert().Equal("body", string(client.body))
 }
+
+func (s *TestOptionSuite) Test_WithJSONBody_ShouldRunSuccesfully() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+	client := New(baseUrl)
+
+	// Act
+	WithJSONBody(map[string]int{"id": 1})(client)
+
+	// Assert
+	s.Assert().Equal(`{"id":1}`, string(client.body))
+	s.Assert().Equal("application/json", client.headers["Content-Type"].Value)
+}
+
+func (s *TestOptionSuite) Test_WithJSONBody_WhenValueIsInvalid_ShouldFail() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+	client := New(baseUrl)
+
+	// Act
+	WithJSONBody(make(chan int))(client)
+
+	// Assert
+	s.Assert().Error(client.err)
+	s.Assert().Nil(client.body)
+}
+
+func (s *TestOptionSuite) Test_WithRecorder_ShouldRunSuccesfully() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+	recorder, _ := NewRecorder("cassette.json", ModeRecord)
+	client := New(baseUrl)
+
+	// Act
+	WithRecorder(recorder)(client)
+
+	// Assert
+	recording, ok := client.httpClient.Transport.(*recordingTransport)
+	s.Require().True(ok)
+	s.Assert().Equal(recorder, recording.recorder)
+	s.Assert().NotNil(recording.transport)
+}
+
+func (s *TestOptionSuite) Test_WithHedging_ShouldRunSuccesfully() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+	client := New(baseUrl)
+
+	// Act
+	WithHedging(time.Second, 2)(client)
+
+	// Assert
+	s.Assert().Equal(time.Second, client.hedging.delay)
+	s.Assert().Equal(2, client.hedging.max)
+}
+
+func (s *TestOptionSuite) Test_WithMaxLineSize_ShouldRunSuccesfully() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+	client := New(baseUrl)
+
+	// Act
+	WithMaxLineSize(64)(client)
+
+	// Assert
+	s.Assert().Equal(64, client.maxLineSize)
+}
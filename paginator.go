@@ -0,0 +1,414 @@
+package gohttpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// PageRequest is the endpoint and the query parameters of a single page
+	PageRequest struct {
+		Endpoint string
+		Query    map[string]string
+	}
+
+	// PageStrategy decides how the request for the following page is built
+	PageStrategy interface {
+		First(endpoint string) PageRequest
+		Next(prev PageRequest, res *Response, items int) (PageRequest, bool, error)
+	}
+
+	Page[T any] struct {
+		Number   int
+		Items    []T
+		Response *Response
+	}
+
+	// Paginator walks a listing endpoint page by page, use it like a bufio.Scanner.
+	// It stops when a strategy asks for a page it has already fetched.
+	Paginator[T any] struct {
+		client   *Client
+		strategy PageStrategy
+		config   paginatorConfig
+
+		next    PageRequest
+		hasNext bool
+		seen    map[string]bool
+		page    Page[T]
+		pages   int
+		err     error
+	}
+
+	// strategyValidator is implemented by strategies who can be built with settings they cannot page with
+	strategyValidator interface {
+		validate() error
+	}
+
+	PaginatorOption func(c *paginatorConfig)
+
+	paginatorConfig struct {
+		maxPages   int
+		itemsField string
+		opts       []Option
+	}
+
+	linkStrategy struct{}
+
+	cursorStrategy struct {
+		field string
+		param string
+	}
+
+	offsetStrategy struct {
+		offsetParam string
+		limitParam  string
+		limit       int
+	}
+)
+
+// NewPaginator func returns a Paginator who starts at endpoint and decodes every page into T items
+func NewPaginator[T any](client *Client, endpoint string, strategy PageStrategy, opts ...PaginatorOption) *Paginator[T] {
+	p := &Paginator[T]{
+		client:   client,
+		strategy: strategy,
+		next:     strategy.First(endpoint),
+		hasNext:  true,
+		seen:     make(map[string]bool),
+	}
+
+	for _, opt := range opts {
+		opt(&p.config)
+	}
+
+	if v, ok := strategy.(strategyValidator); ok {
+		p.err = v.validate()
+	}
+
+	return p
+}
+
+// WithMaxPages stops the paginator after n pages
+func WithMaxPages(n int) PaginatorOption {
+	return func(c *paginatorConfig) {
+		c.maxPages = n
+	}
+}
+
+// WithItemsField decodes the items from a field of the body instead of a top level array,
+// nested fields are separated by dots
+func WithItemsField(field string) PaginatorOption {
+	return func(c *paginatorConfig) {
+		c.itemsField = field
+	}
+}
+
+// WithPageOptions adds request options who are sent with every page
+func WithPageOptions(opts ...Option) PaginatorOption {
+	return func(c *paginatorConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+// LinkHeader follows the rel="next" entry of the Link header
+func LinkHeader() PageStrategy {
+	return linkStrategy{}
+}
+
+// Cursor reads the next cursor from a field of the body and sends it as the param query parameter,
+// it stops when the cursor is missing or empty
+func Cursor(field, param string) PageStrategy {
+	return cursorStrategy{field: field, param: param}
+}
+
+// Offset moves offsetParam forward by limit until a page returns less than limit items, limit must be positive
+func Offset(offsetParam, limitParam string, limit int) PageStrategy {
+	return offsetStrategy{offsetParam: offsetParam, limitParam: limitParam, limit: limit}
+}
+
+// Next func fetches the following page, it returns false when there are no pages left or an error occurred
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	if p.err != nil || !p.hasNext {
+		return false
+	}
+
+	if p.config.maxPages > 0 && p.pages >= p.config.maxPages {
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	p.seen[p.next.key(p.client.baseUrl)] = true
+	res, err := p.fetch(ctx, p.next)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	items, err := p.decode(res)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	next, hasNext, err := p.strategy.Next(p.next, res, len(items))
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.pages++
+	p.page = Page[T]{Number: p.pages, Items: items, Response: res}
+	p.next, p.hasNext = next, hasNext && !p.seen[next.key(p.client.baseUrl)]
+	return true
+}
+
+// Page func returns the page fetched by the last Next call
+func (p *Paginator[T]) Page() Page[T] {
+	return p.page
+}
+
+// Err func returns the error who stopped the paginator
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// ForEach func calls fn for every item of every page, returning an error from fn stops the iteration
+func (p *Paginator[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	for p.Next(ctx) {
+		for _, item := range p.page.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return p.Err()
+}
+
+// All func collects the items of every remaining page
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	err := p.ForEach(ctx, func(item T) error {
+		items = append(items, item)
+		return nil
+	})
+
+	return items, err
+}
+
+func (p *Paginator[T]) fetch(ctx context.Context, page PageRequest) (*Response, error) {
+	client, endpoint := p.client, page.Endpoint
+	opts := append([]Option{}, p.config.opts...)
+	for key, value := range page.Query {
+		opts = append(opts, WithQuery(key, value))
+	}
+
+	if u, err := url.Parse(endpoint); err == nil && u.IsAbs() {
+		// absolute links are sent as they are instead of being joined with the base url
+		client = client.withOpts()
+		client.baseUrl = ""
+
+		// a server must not be able to send the credentials of the caller to a host it names,
+		// the headers are dropped as http.Client does on cross host redirects
+		if base, err := url.Parse(p.client.baseUrl); err != nil || !strings.EqualFold(base.Host, u.Host) {
+			opts = append(opts, withoutCredentials)
+		}
+	}
+
+	res, err := client.Get(ctx, endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Ok() {
+		return nil, errors.Errorf("unexpected status code %d for page %d", res.Status(), p.pages+1)
+	}
+
+	return res, nil
+}
+
+// key identifies the page request, relative endpoints are joined with baseUrl as the client does
+// so a resolved link matches the endpoint it points to, the query is encoded in a stable order
+func (r PageRequest) key(baseUrl string) string {
+	endpoint := r.Endpoint
+	if u, err := url.Parse(endpoint); err != nil || !u.IsAbs() {
+		endpoint = baseUrl + endpoint
+	}
+
+	query := make(url.Values, len(r.Query))
+	for key, value := range r.Query {
+		query.Set(key, value)
+	}
+
+	return endpoint + "?" + query.Encode()
+}
+
+// withoutCredentials removes the Authorization header and every header who is not a default one
+func withoutCredentials(c *Client) {
+	for key, header := range c.headers {
+		if !header.IsDefault || strings.EqualFold(key, "Authorization") {
+			delete(c.headers, key)
+		}
+	}
+}
+
+func (p *Paginator[T]) decode(res *Response) ([]T, error) {
+	var items []T
+	if p.config.itemsField == "" {
+		if err := res.Unmarshal(&items); err != nil {
+			return nil, errors.Wrap(err, "failed to decode page items")
+		}
+
+		return items, nil
+	}
+
+	raw, err := lookupField(res.Body(), p.config.itemsField)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, errors.Wrap(err, "failed to decode page items")
+	}
+
+	return items, nil
+}
+
+func (linkStrategy) First(endpoint string) PageRequest {
+	return PageRequest{Endpoint: endpoint}
+}
+
+func (linkStrategy) Next(prev PageRequest, res *Response, items int) (PageRequest, bool, error) {
+	for _, link := range res.Links() {
+		if link.Rel != "next" {
+			continue
+		}
+
+		next, err := resolveReference(res, link.URL)
+		if err != nil {
+			return PageRequest{}, false, errors.Wrap(err, "failed to parse next link")
+		}
+
+		return PageRequest{Endpoint: next}, true, nil
+	}
+
+	return PageRequest{}, false, nil
+}
+
+func (s cursorStrategy) First(endpoint string) PageRequest {
+	return PageRequest{Endpoint: endpoint}
+}
+
+func (s cursorStrategy) Next(prev PageRequest, res *Response, items int) (PageRequest, bool, error) {
+	raw, err := lookupField(res.Body(), s.field)
+	if err != nil || raw == nil {
+		return PageRequest{}, false, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var cursor interface{}
+	if err := decoder.Decode(&cursor); err != nil {
+		return PageRequest{}, false, errors.Wrap(err, "failed to decode cursor")
+	}
+
+	value := ""
+	switch v := cursor.(type) {
+	case string:
+		value = v
+	case json.Number:
+		value = v.String()
+	case nil:
+	default:
+		return PageRequest{}, false, errors.Errorf("cursor field %q is not a string or a number", s.field)
+	}
+
+	if value == "" {
+		return PageRequest{}, false, nil
+	}
+
+	return PageRequest{Endpoint: prev.Endpoint, Query: map[string]string{s.param: value}}, true, nil
+}
+
+func (s offsetStrategy) First(endpoint string) PageRequest {
+	return PageRequest{Endpoint: endpoint, Query: s.query(0)}
+}
+
+func (s offsetStrategy) Next(prev PageRequest, res *Response, items int) (PageRequest, bool, error) {
+	if items < s.limit {
+		return PageRequest{}, false, nil
+	}
+
+	offset, err := strconv.Atoi(prev.Query[s.offsetParam])
+	if err != nil {
+		return PageRequest{}, false, errors.Wrap(err, "failed to parse offset")
+	}
+
+	return PageRequest{Endpoint: prev.Endpoint, Query: s.query(offset + s.limit)}, true, nil
+}
+
+func (s offsetStrategy) validate() error {
+	if s.limit <= 0 {
+		return errors.Errorf("offset limit must be positive, got %d", s.limit)
+	}
+
+	return nil
+}
+
+func (s offsetStrategy) query(offset int) map[string]string {
+	return map[string]string{
+		s.offsetParam: strconv.Itoa(offset),
+		s.limitParam:  strconv.Itoa(s.limit),
+	}
+}
+
+// lookupField returns the raw json of a dotted field path, nil when a part of the path is missing
+func lookupField(body []byte, path string) (json.RawMessage, error) {
+	raw := json.RawMessage(body)
+	for _, key := range strings.Split(path, ".") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode field %q", path)
+		}
+
+		value, ok := object[key]
+		if !ok {
+			return nil, nil
+		}
+
+		raw = value
+	}
+
+	if string(raw) == "null" {
+		return nil, nil
+	}
+
+	return raw, nil
+}
+
+func resolveReference(res *Response, target string) (string, error) {
+	ref, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.IsAbs() || res.Get() == nil || res.Get().Request == nil {
+		return ref.String(), nil
+	}
+
+	return res.Get().Request.URL.ResolveReference(ref).String(), nil
+}
@@ -0,0 +1,311 @@
+package gohttpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestPaginatorSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+type testItem struct {
+	ID int `json:"id"`
+}
+
+func TestPaginator(t *testing.T) {
+	suite.Run(t, new(TestPaginatorSuite))
+}
+
+func (s *TestPaginatorSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestPaginatorSuite) Test_LinkHeader_ShouldFollowNextLinks() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`</items?page=%d>; rel="next", </items?page=2>; rel="last"`, page+1))
+		}
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, page*2, page*2+1)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items?page=0", LinkHeader())
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]testItem{{0}, {1}, {2}, {3}, {4}, {5}}, items)
+}
+
+func (s *TestPaginatorSuite) Test_LinkHeader_WhenNextLinkIsOnAnotherHost_ShouldDropCredentials() {
+	// Arrange
+	foreignHeaders := make(chan http.Header, 1)
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignHeaders <- r.Header.Clone()
+		w.Write([]byte(`[{"id":2}]`))
+	}))
+	defer foreign.Close()
+
+	ownHeaders := make(chan http.Header, 1)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ownHeaders <- r.Header.Clone()
+		w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=2>; rel="next"`, foreign.URL))
+		w.Write([]byte(`[{"id":1}]`))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithDefaultHeaders())
+	paginator := NewPaginator[testItem](client, "/items", LinkHeader(),
+		WithPageOptions(WithHeader("Authorization", "token"), WithHeader("X-Tenant", "acme")))
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]testItem{{1}, {2}}, items)
+
+	own := <-ownHeaders
+	s.Equal("token", own.Get("Authorization"))
+	s.Equal("acme", own.Get("X-Tenant"))
+
+	other := <-foreignHeaders
+	s.Empty(other.Get("Authorization"))
+	s.Empty(other.Get("X-Tenant"))
+	s.Equal("application/json", other.Get("Accept"))
+}
+
+func (s *TestPaginatorSuite) Test_Cursor_ShouldSendCursorFromBody() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("token", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"data":[{"id":1}],"meta":{"next":"abc"}}`))
+		case "abc":
+			w.Write([]byte(`{"data":[{"id":2}],"meta":{"next":null}}`))
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", Cursor("meta.next", "cursor"),
+		WithItemsField("data"), WithPageOptions(WithHeader("Authorization", "token")))
+
+	// Act
+	var pages []Page[testItem]
+	for paginator.Next(s.ctx) {
+		pages = append(pages, paginator.Page())
+	}
+
+	// Assert
+	s.NoError(paginator.Err())
+	s.Len(pages, 2)
+	s.Equal(1, pages[0].Number)
+	s.Equal([]testItem{{1}}, pages[0].Items)
+	s.Equal([]testItem{{2}}, pages[1].Items)
+	s.NotNil(pages[1].Response)
+}
+
+func (s *TestPaginatorSuite) Test_Offset_ShouldStopOnShortPage() {
+	// Arrange
+	var requests int
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		s.Equal("2", r.URL.Query().Get("limit"))
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`[{"id":1},{"id":2}]`))
+		case "2":
+			w.Write([]byte(`[{"id":3}]`))
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", Offset("offset", "limit", 2))
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(2, requests)
+	s.Equal([]testItem{{1}, {2}, {3}}, items)
+}
+
+func (s *TestPaginatorSuite) Test_Offset_WhenLimitIsNotPositive_ShouldReturnError() {
+	// Arrange
+	var requests int
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"id":1}]`))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", Offset("offset", "limit", 0))
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.Nil(items)
+	s.EqualError(err, "offset limit must be positive, got 0")
+	s.Equal(0, requests)
+}
+
+func (s *TestPaginatorSuite) Test_Cursor_WhenCursorRepeats_ShouldStop() {
+	// Arrange
+	var requests int
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":[{"id":1}],"next":"abc"}`))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", Cursor("next", "cursor"), WithItemsField("data"))
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(2, requests)
+	s.Len(items, 2)
+}
+
+func (s *TestPaginatorSuite) Test_Cursor_WhenCursorsCycle_ShouldStop() {
+	// Arrange
+	var requests int
+	next := map[string]string{"": "A", "A": "B", "B": "A"}
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"data":[{"id":%d}],"next":"%s"}`, requests, next[r.URL.Query().Get("cursor")])
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", Cursor("next", "cursor"), WithItemsField("data"))
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(3, requests)
+	s.Equal([]testItem{{1}, {2}, {3}}, items)
+}
+
+func (s *TestPaginatorSuite) Test_LinkHeader_WhenNextLinkRepeats_ShouldStop() {
+	// Arrange
+	var requests int
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", `</items>; rel="next"`)
+		w.Write([]byte(`[{"id":1}]`))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", LinkHeader())
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(1, requests)
+	s.Len(items, 1)
+}
+
+func (s *TestPaginatorSuite) Test_WithMaxPages_ShouldStopPaginator() {
+	// Arrange
+	var requests int
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", Offset("offset", "limit", 2), WithMaxPages(3))
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(3, requests)
+	s.Len(items, 6)
+}
+
+func (s *TestPaginatorSuite) Test_Next_WhenContextIsCancelled_ShouldReturnError() {
+	// Arrange
+	client := New("http://localhost:8080")
+	paginator := NewPaginator[testItem](client, "/items", LinkHeader())
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	// Act
+	ok := paginator.Next(ctx)
+
+	// Assert
+	s.False(ok)
+	s.Equal(context.Canceled, paginator.Err())
+}
+
+func (s *TestPaginatorSuite) Test_Next_WhenStatusIsNotOk_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", LinkHeader())
+
+	// Act
+	items, err := paginator.All(s.ctx)
+
+	// Assert
+	s.Nil(items)
+	s.Error(err)
+}
+
+func (s *TestPaginatorSuite) Test_ForEach_WhenCallbackReturnsError_ShouldStop() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	paginator := NewPaginator[testItem](client, "/items", LinkHeader())
+	stop := fmt.Errorf("stop")
+
+	// Act
+	var seen int
+	err := paginator.ForEach(s.ctx, func(item testItem) error {
+		seen++
+		return stop
+	})
+
+	// Assert
+	s.Equal(stop, err)
+	s.Equal(1, seen)
+}
@@ -0,0 +1,48 @@
+package gohttpclient
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	protoCodec struct{}
+)
+
+const (
+	MediaTypeProtobuf = "application/x-protobuf"
+)
+
+func (protoCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("protobuf codec can not encode %T", v)
+	}
+
+	return proto.Marshal(m)
+}
+
+func (protoCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return errors.Errorf("protobuf codec can not decode into %T", v)
+	}
+
+	return proto.Unmarshal(data, m)
+}
+
+// UnmarshalProto func decodes a protobuf body into m, whatever the status code is
+func (r *Response) UnmarshalProto(m proto.Message) error {
+	return proto.Unmarshal(r.body, m)
+}
+
+// RPCStatus func decodes a google.rpc.Status error body, as protobuf services send with non-2xx responses
+func (r *Response) RPCStatus() (*status.Status, error) {
+	s := &status.Status{}
+	if err := r.UnmarshalProto(s); err != nil {
+		return nil, errors.Wrap(err, "failed to decode google.rpc.Status")
+	}
+
+	return s, nil
+}
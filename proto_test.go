@@ -0,0 +1,99 @@
+package gohttpclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type TestProtoSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestProto(t *testing.T) {
+	suite.Run(t, new(TestProtoSuite))
+}
+
+func (s *TestProtoSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestProtoSuite) Test_WithProtoBody_ShouldRoundTrip() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		in := &wrapperspb.StringValue{}
+		if err := proto.Unmarshal(body, in); err != nil || r.Header.Get("Content-Type") != MediaTypeProtobuf {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		out, _ := proto.Marshal(wrapperspb.String("echo " + in.Value))
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+		w.Write(out)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Post(s.ctx, "/", WithProtoBody(wrapperspb.String("hello")))
+	s.Require().NoError(err)
+
+	var out wrapperspb.StringValue
+	unmarshalErr := response.UnmarshalProto(&out)
+
+	var decoded wrapperspb.StringValue
+	decodeErr := response.Decode(&decoded)
+
+	// Assert
+	s.True(response.Ok())
+	s.NoError(unmarshalErr)
+	s.NoError(decodeErr)
+	s.Equal("echo hello", out.Value)
+	s.Equal("echo hello", decoded.Value)
+}
+
+func (s *TestProtoSuite) Test_RPCStatus_ShouldDecodeErrorBody() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := proto.Marshal(&status.Status{Code: 5, Message: "post not found"})
+		w.Header().Set("Content-Type", MediaTypeProtobuf)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(body)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/posts/1")
+	s.Require().NoError(err)
+	rpcStatus, statusErr := response.RPCStatus()
+
+	// Assert
+	s.NoError(statusErr)
+	s.Equal(http.StatusNotFound, response.Status())
+	s.Equal(int32(5), rpcStatus.Code)
+	s.Equal("post not found", rpcStatus.Message)
+}
+
+func (s *TestProtoSuite) Test_WithProtoBody_WhenValueIsNotMessage_ShouldReturnError() {
+	// Arrange
+	client := New("http://localhost:8080")
+
+	// Act
+	response, err := client.Post(s.ctx, "/", WithEncodedBody(MediaTypeProtobuf, "text"))
+
+	// Assert
+	s.Nil(response)
+	s.EqualError(err, "failed to encode application/x-protobuf body: protobuf codec can not encode string")
+}
@@ -0,0 +1,226 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// bufferedConn keeps the bytes the proxy sent right after its CONNECT response
+	bufferedConn struct {
+		net.Conn
+		r io.Reader
+	}
+)
+
+// tunnel opens a CONNECT tunnel through the proxy at the base url, only the handshake is bound to the client timeout
+func (c *Client) tunnel(ctx context.Context, target string) (net.Conn, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodConnect, c.baseUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	target = strings.TrimPrefix(target, "/")
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		return nil, errors.Wrap(err, "connect endpoint must be a host:port pair")
+	}
+
+	proxyUrl := req.URL
+	req.URL = &url.URL{Host: target}
+	req.Host = target
+	req, err = c.prepareReq(req)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = ""
+
+	if proxyUrl.User != nil {
+		req.Header.Set("Proxy-Authorization", basicAuth(proxyUrl.User))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	conn, err := c.dialProxy(ctx, proxyUrl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to dial proxy")
+	}
+
+	// the handshake has to respect ctx, the tunnel itself must outlive it
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+
+	br := bufio.NewReader(conn)
+	res, err := writeConnect(conn, br, req)
+	close(done)
+	<-stopped
+
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(ctx.Err(), "failed to send request")
+		}
+
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		conn.Close()
+		return nil, errors.Errorf("proxy refused the tunnel with status code %d", res.StatusCode)
+	}
+
+	conn.SetDeadline(time.Time{})
+	return &bufferedConn{Conn: conn, r: br}, nil
+}
+
+func (c *Client) dialProxy(ctx context.Context, proxyUrl *url.URL) (net.Conn, error) {
+	addr := proxyUrl.Host
+	if proxyUrl.Port() == "" {
+		port := "80"
+		if proxyUrl.Scheme == "https" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(proxyUrl.Hostname(), port)
+	}
+
+	transport := c.currentTransport()
+
+	dial := (&net.Dialer{}).DialContext
+	if transport != nil && transport.DialContext != nil {
+		dial = transport.DialContext
+	}
+
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil || proxyUrl.Scheme != "https" {
+		return conn, err
+	}
+
+	config := &tls.Config{}
+	if transport != nil && transport.TLSClientConfig != nil {
+		config = transport.TLSClientConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = proxyUrl.Hostname()
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+func writeConnect(conn net.Conn, br *bufio.Reader, req *http.Request) (*http.Response, error) {
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	// the body is left alone, after a 2xx everything that follows belongs to the tunnel
+	return http.ReadResponse(br, req)
+}
+
+// proxyFunc returns a http.Transport Proxy func who sends every request through proxyUrl
+// except the hosts matching the noProxy list
+func proxyFunc(proxyUrl string, noProxy []string) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+
+		u, err := url.Parse(proxyUrl)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid proxy url")
+		}
+
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+			return u, nil
+		}
+
+		return nil, errors.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+}
+
+// bypassProxy matches the host against a NO_PROXY style list. Entries are "*", IP addresses,
+// CIDR ranges or domain names who also match their subdomains, each optionally with a port.
+func bypassProxy(target *url.URL, noProxy []string) bool {
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = "80"
+		if target.Scheme == "https" || target.Scheme == "wss" {
+			port = "443"
+		}
+	}
+
+	ip := net.ParseIP(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if entry == "*" {
+			return true
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost, entryPort := entry, ""
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			entryHost, entryPort = h, p
+		}
+
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+
+		if entryIP := net.ParseIP(entryHost); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost = strings.TrimPrefix(entryHost, "*")
+		domain := strings.TrimPrefix(entryHost, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func basicAuth(user *url.Userinfo) string {
+	password, _ := user.Password()
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password))
+}
+
+func (b *bufferedConn) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
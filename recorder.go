@@ -0,0 +1,413 @@
+package gohttpclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// RecorderMode decides whether a Recorder talks to the network or plays back a cassette
+	RecorderMode int
+
+	// Recorder is a http.RoundTripper who records and replays interactions from a cassette file
+	Recorder struct {
+		path      string
+		mode      RecorderMode
+		matcher   Matcher
+		scrubbers []Scrubber
+		transport http.RoundTripper
+
+		mu       sync.Mutex
+		cassette *Cassette
+		used     map[int]bool
+		changed  bool
+	}
+
+	// recordingTransport records the requests of a single client through a shared Recorder,
+	// the network is reached through the own transport of that client
+	recordingTransport struct {
+		recorder  *Recorder
+		transport http.RoundTripper
+	}
+
+	RecorderOption func(r *Recorder)
+
+	// Matcher reports whether a recorded interaction can answer the request
+	Matcher func(req *http.Request, i Interaction) bool
+
+	// Scrubber mutates an interaction before it is stored
+	Scrubber func(i *Interaction)
+
+	Cassette struct {
+		Interactions []Interaction `json:"interactions" yaml:"interactions"`
+	}
+
+	Interaction struct {
+		Request  RecordedRequest  `json:"request" yaml:"request"`
+		Response RecordedResponse `json:"response" yaml:"response"`
+	}
+
+	// RecordedRequest and RecordedResponse keep text bodies as they are, a body who is not valid
+	// UTF-8 is stored base64 encoded with BodyEncoding set to BODY_ENCODING_BASE64
+	RecordedRequest struct {
+		Method       string      `json:"method" yaml:"method"`
+		URL          string      `json:"url" yaml:"url"`
+		Headers      http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+		Body         string      `json:"body,omitempty" yaml:"body,omitempty"`
+		BodyEncoding string      `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"`
+	}
+
+	RecordedResponse struct {
+		Status       int         `json:"status" yaml:"status"`
+		Headers      http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+		Body         string      `json:"body,omitempty" yaml:"body,omitempty"`
+		BodyEncoding string      `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"`
+	}
+)
+
+const (
+	// ModeRecord always sends requests and overwrites the cassette
+	ModeRecord RecorderMode = iota
+	// ModeReplay only answers from the cassette and never touches the network
+	ModeReplay
+	// ModeRecordMissing replays known requests and records the rest
+	ModeRecordMissing
+)
+
+const (
+	REDACTED = "[REDACTED]"
+
+	BODY_ENCODING_BASE64 = "base64"
+)
+
+var (
+	ErrInteractionNotFound = errors.New("no recorded interaction matches the request")
+)
+
+// NewRecorder func returns a Recorder who reads and writes the cassette at path.
+// Cassettes with a .yaml or .yml extension are stored as YAML, anything else as JSON.
+func NewRecorder(path string, mode RecorderMode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		mode:     mode,
+		matcher:  DefaultMatcher,
+		cassette: &Cassette{},
+		used:     make(map[int]bool),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && mode == ModeRecordMissing {
+			return r, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to read cassette")
+	}
+
+	if err := r.decode(data); err != nil {
+		return nil, errors.Wrap(err, "failed to decode cassette")
+	}
+
+	return r, nil
+}
+
+// WithMatcher replaces the DefaultMatcher of the recorder
+func WithMatcher(matcher Matcher) RecorderOption {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// WithScrubber adds a scrubber who runs on every recorded interaction
+func WithScrubber(scrubber Scrubber) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrubber)
+	}
+}
+
+// WithScrubbedHeaders redacts the given request and response headers before they are stored
+func WithScrubbedHeaders(keys ...string) RecorderOption {
+	return WithScrubber(func(i *Interaction) {
+		for _, key := range keys {
+			if i.Request.Headers.Get(key) != "" {
+				i.Request.Headers.Set(key, REDACTED)
+			}
+			if i.Response.Headers.Get(key) != "" {
+				i.Response.Headers.Set(key, REDACTED)
+			}
+		}
+	})
+}
+
+// WithRecorderTransport sets the transport used when the recorder hits the network
+func WithRecorderTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// DefaultMatcher matches the method and the full url of the request
+func DefaultMatcher(req *http.Request, i Interaction) bool {
+	return req.Method == i.Request.Method && req.URL.String() == i.Request.URL
+}
+
+// MatchBody matches like DefaultMatcher and additionally compares the request body
+func MatchBody(req *http.Request, i Interaction) bool {
+	if !DefaultMatcher(req, i) {
+		return false
+	}
+
+	body, err := peekBody(req)
+	if err != nil {
+		return false
+	}
+
+	recorded, err := decodeRecordedBody(i.Request.Body, i.Request.BodyEncoding)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(body, recorded)
+}
+
+// RoundTrip func answers the request from the cassette or the network depending on the mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.roundTrip(req, r.transport)
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.recorder.roundTrip(req, t.transport)
+}
+
+// roundTrip answers the request from the cassette or sends it through transport
+func (r *Recorder) roundTrip(req *http.Request, transport http.RoundTripper) (*http.Response, error) {
+	if r.mode != ModeRecord {
+		if i, ok := r.find(req); ok {
+			return i.Response.toHttp(req)
+		}
+
+		if r.mode == ModeReplay {
+			return nil, errors.Wrapf(ErrInteractionNotFound, "%s %s", req.Method, req.URL)
+		}
+	}
+
+	reqBody, err := peekBody(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	// bodies are stored decoded, a compressed one would not survive as a string in the cassette
+	decoded, ok, err := decodeContent(res.Header, resBody, DEFAULT_MAX_DECOMPRESSED_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		resBody = decoded
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+		res.ContentLength = int64(len(resBody))
+		res.Uncompressed = true
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	i := Interaction{
+		Request:  RecordedRequest{Method: req.Method, URL: req.URL.String(), Headers: req.Header.Clone()},
+		Response: RecordedResponse{Status: res.StatusCode, Headers: res.Header.Clone()},
+	}
+	i.Request.Body, i.Request.BodyEncoding = encodeRecordedBody(reqBody)
+	i.Response.Body, i.Response.BodyEncoding = encodeRecordedBody(resBody)
+	r.append(i)
+
+	return res, nil
+}
+
+// Cassette func returns the interactions the recorder currently holds
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := make([]Interaction, len(r.cassette.Interactions))
+	copy(interactions, r.cassette.Interactions)
+	return Cassette{Interactions: interactions}
+}
+
+// Save func writes the cassette to disk if something new was recorded
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.changed {
+		return nil
+	}
+
+	data, err := r.encode()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode cassette")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create cassette directory")
+	}
+
+	if err := ioutil.WriteFile(r.path, data, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write cassette")
+	}
+
+	r.changed = false
+	return nil
+}
+
+func (r *Recorder) find(req *http.Request) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// prefer interactions who have not been played yet so repeated requests replay in order
+	fallback := -1
+	for idx, i := range r.cassette.Interactions {
+		if !r.matcher(req, i) {
+			continue
+		}
+
+		if !r.used[idx] {
+			r.used[idx] = true
+			return i, true
+		}
+
+		fallback = idx
+	}
+
+	if fallback >= 0 {
+		return r.cassette.Interactions[fallback], true
+	}
+
+	return Interaction{}, false
+}
+
+func (r *Recorder) append(i Interaction) {
+	for _, scrub := range r.scrubbers {
+		scrub(&i)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.used[len(r.cassette.Interactions)] = true
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.changed = true
+}
+
+func (r *Recorder) isYaml() bool {
+	ext := strings.ToLower(filepath.Ext(r.path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func (r *Recorder) decode(data []byte) error {
+	if r.isYaml() {
+		return yaml.Unmarshal(data, r.cassette)
+	}
+
+	return json.Unmarshal(data, r.cassette)
+}
+
+func (r *Recorder) encode() ([]byte, error) {
+	if r.isYaml() {
+		return yaml.Marshal(r.cassette)
+	}
+
+	return json.MarshalIndent(r.cassette, "", "  ")
+}
+
+func (rr RecordedResponse) toHttp(req *http.Request) (*http.Response, error) {
+	body, err := decodeRecordedBody(rr.Body, rr.BodyEncoding)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode recorded response body")
+	}
+
+	header := rr.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(rr.Status),
+		StatusCode:    rr.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// encodeRecordedBody returns body as a string who survives JSON and YAML, with its encoding
+func encodeRecordedBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), BODY_ENCODING_BASE64
+}
+
+func decodeRecordedBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case BODY_ENCODING_BASE64:
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return nil, errors.Errorf("unknown body encoding %q", encoding)
+}
+
+// peekBody reads the request body and puts an identical reader back in its place
+func peekBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,350 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestRecorderSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestRecorder(t *testing.T) {
+	suite.Run(t, new(TestRecorderSuite))
+}
+
+func (s *TestRecorderSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestRecorderSuite) Test_NewRecorder_WhenCassetteIsMissingInReplayMode_ShouldReturnError() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "missing.json")
+
+	// Act
+	recorder, err := NewRecorder(path, ModeReplay)
+
+	// Assert
+	s.Nil(recorder)
+	s.Error(err)
+}
+
+func (s *TestRecorderSuite) Test_NewRecorder_WhenCassetteIsMissingInRecordMissingMode_ShouldRunSuccesfully() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "missing.json")
+
+	// Act
+	recorder, err := NewRecorder(path, ModeRecordMissing)
+
+	// Assert
+	s.NoError(err)
+	s.NotNil(recorder)
+	s.Empty(recorder.Cassette().Interactions)
+}
+
+func (s *TestRecorderSuite) Test_NewRecorder_WhenCassetteIsInvalid_ShouldReturnError() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "invalid.json")
+	s.Require().NoError(ioutil.WriteFile(path, []byte("{"), 0o644))
+
+	// Act
+	recorder, err := NewRecorder(path, ModeReplay)
+
+	// Assert
+	s.Nil(recorder)
+	s.Error(err)
+}
+
+func (s *TestRecorderSuite) Test_Record_ThenReplay_ShouldRunSuccesfully() {
+	for _, name := range []string{"cassette.json", "cassette.yaml"} {
+		s.Suite.Run(name, func() {
+			// Arrange
+			var hits int32
+			svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				w.Header().Set("X-Token", "secret")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"name":"test"}`))
+			}))
+			defer svc.Close()
+
+			path := filepath.Join(s.T().TempDir(), name)
+			recorder, err := NewRecorder(path, ModeRecord, WithScrubbedHeaders("Authorization", "X-Token"))
+			s.Require().NoError(err)
+
+			client := New(svc.URL, WithRecorder(recorder))
+			_, err = client.Post(s.ctx, "/posts", WithHeader("Authorization", "Bearer token"), WithBody([]byte("body")))
+			s.Require().NoError(err)
+			s.Require().NoError(recorder.Save())
+
+			// Act
+			replayer, err := NewRecorder(path, ModeReplay)
+			s.Require().NoError(err)
+
+			client = New(svc.URL, WithRecorder(replayer))
+			response, err := client.Post(s.ctx, "/posts")
+
+			// Assert
+			s.NoError(err)
+			s.Equal(int32(1), atomic.LoadInt32(&hits))
+			s.Equal(http.StatusCreated, response.Status())
+			s.Equal(`{"name":"test"}`, string(response.Body()))
+			s.Equal(REDACTED, response.Headers().Get("X-Token"))
+
+			interaction := replayer.Cassette().Interactions[0]
+			s.Equal(REDACTED, interaction.Request.Headers.Get("Authorization"))
+			s.Equal("body", interaction.Request.Body)
+		})
+	}
+}
+
+func (s *TestRecorderSuite) Test_Record_ThenReplay_WhenResponseIsCompressed_ShouldStoreDecodedBody() {
+	for _, name := range []string{"cassette.json", "cassette.yaml"} {
+		s.Suite.Run(name, func() {
+			// Arrange
+			var compressed bytes.Buffer
+			w := gzip.NewWriter(&compressed)
+			w.Write([]byte(`{"name":"compressed"}`))
+			w.Close()
+
+			svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Write(compressed.Bytes())
+			}))
+			defer svc.Close()
+
+			path := filepath.Join(s.T().TempDir(), name)
+			recorder, err := NewRecorder(path, ModeRecord)
+			s.Require().NoError(err)
+
+			recorded, err := New(svc.URL, WithRecorder(recorder)).Get(s.ctx, "/posts")
+			s.Require().NoError(err)
+			s.Require().NoError(recorder.Save())
+
+			// Act
+			replayer, err := NewRecorder(path, ModeReplay)
+			s.Require().NoError(err)
+
+			response, err := New(svc.URL, WithRecorder(replayer)).Get(s.ctx, "/posts")
+
+			// Assert
+			s.NoError(err)
+			s.Equal(`{"name":"compressed"}`, string(recorded.Body()))
+			s.Equal(`{"name":"compressed"}`, string(response.Body()))
+
+			interaction := replayer.Cassette().Interactions[0]
+			s.Equal(`{"name":"compressed"}`, interaction.Response.Body)
+			s.Empty(interaction.Response.Headers.Get("Content-Encoding"))
+		})
+	}
+}
+
+func (s *TestRecorderSuite) Test_Record_ThenReplay_WhenBodyIsBinary_ShouldKeepBytes() {
+	for _, name := range []string{"cassette.json", "cassette.yaml"} {
+		s.Suite.Run(name, func() {
+			// Arrange
+			binary := []byte{0x08, 0x96, 0x01, 0xff}
+			svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write(binary)
+			}))
+			defer svc.Close()
+
+			path := filepath.Join(s.T().TempDir(), name)
+			recorder, err := NewRecorder(path, ModeRecord)
+			s.Require().NoError(err)
+
+			_, err = New(svc.URL, WithRecorder(recorder)).Post(s.ctx, "/proto", WithBody(binary))
+			s.Require().NoError(err)
+			s.Require().NoError(recorder.Save())
+
+			// Act
+			replayer, err := NewRecorder(path, ModeReplay, WithMatcher(MatchBody))
+			s.Require().NoError(err)
+
+			response, err := New(svc.URL, WithRecorder(replayer)).Post(s.ctx, "/proto", WithBody(binary))
+
+			// Assert
+			s.NoError(err)
+			s.Equal(binary, response.Body())
+
+			interaction := replayer.Cassette().Interactions[0]
+			s.Equal(BODY_ENCODING_BASE64, interaction.Request.BodyEncoding)
+			s.Equal(BODY_ENCODING_BASE64, interaction.Response.BodyEncoding)
+		})
+	}
+}
+
+func (s *TestRecorderSuite) Test_WithRecorder_WhenStreaming_ShouldNotRecord() {
+	// Arrange
+	sse := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: live\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer sse.Close()
+
+	ws := newWebSocketServer(func(conn net.Conn, br *bufio.Reader, r *http.Request) {
+		readFrame(br, true)
+	})
+	defer ws.Close()
+
+	recorder, err := NewRecorder(filepath.Join(s.T().TempDir(), "cassette.json"), ModeRecord)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(s.ctx, time.Second)
+	defer cancel()
+
+	// Act
+	stream, streamErr := New(sse.URL, WithRecorder(recorder)).SSE(ctx, "/events")
+	conn, wsErr := New(ws.URL, WithRecorder(recorder)).WebSocket(ctx, "/ws")
+
+	// Assert
+	s.Require().NoError(streamErr)
+	defer stream.Close()
+	s.Equal("live", (<-stream.Events()).Data)
+
+	s.Require().NoError(wsErr)
+	s.NoError(conn.Close())
+	s.Empty(recorder.Cassette().Interactions)
+}
+
+func (s *TestRecorderSuite) Test_WithRecorder_WhenRecorderIsShared_ShouldKeepTransportsApart() {
+	// Arrange
+	svc := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svc.Close()
+
+	roots := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svc.Certificate().Raw})
+	recorder, err := NewRecorder(filepath.Join(s.T().TempDir(), "cassette.json"), ModeRecord)
+	s.Require().NoError(err)
+
+	untrusting := New(svc.URL, WithRecorder(recorder))
+	trusting := New(svc.URL, WithRecorder(recorder), WithRootCAs(roots))
+
+	// Act
+	_, untrustingErr := untrusting.Get(s.ctx, "/")
+	_, trustingErr := trusting.Get(s.ctx, "/")
+
+	// Assert
+	s.Error(untrustingErr)
+	s.NoError(trustingErr)
+	s.NotSame(untrusting.currentTransport(), trusting.currentTransport())
+	s.Nil(untrusting.currentTransport().TLSClientConfig.RootCAs)
+	s.Len(recorder.Cassette().Interactions, 1)
+}
+
+func (s *TestRecorderSuite) Test_Replay_WhenInteractionIsMissing_ShouldReturnError() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "cassette.json")
+	s.Require().NoError(ioutil.WriteFile(path, []byte(`{"interactions":[]}`), 0o644))
+
+	recorder, err := NewRecorder(path, ModeReplay)
+	s.Require().NoError(err)
+	client := New("http://localhost:8080", WithRecorder(recorder))
+
+	// Act
+	response, err := client.Get(s.ctx, "/posts")
+
+	// Assert
+	s.Nil(response)
+	s.True(errors.Is(err, ErrInteractionNotFound))
+}
+
+func (s *TestRecorderSuite) Test_Replay_WhenRequestsRepeat_ShouldReplayInOrder() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "cassette.json")
+	cassette := `{"interactions":[
+		{"request":{"method":"GET","url":"http://localhost:8080/count"},"response":{"status":200,"body":"1"}},
+		{"request":{"method":"GET","url":"http://localhost:8080/count"},"response":{"status":200,"body":"2"}}
+	]}`
+	s.Require().NoError(ioutil.WriteFile(path, []byte(cassette), 0o644))
+
+	recorder, err := NewRecorder(path, ModeReplay)
+	s.Require().NoError(err)
+	client := New("http://localhost:8080", WithRecorder(recorder))
+
+	// Act
+	first, err1 := client.Get(s.ctx, "/count")
+	second, err2 := client.Get(s.ctx, "/count")
+	third, err3 := client.Get(s.ctx, "/count")
+
+	// Assert
+	s.NoError(err1)
+	s.NoError(err2)
+	s.NoError(err3)
+	s.Equal("1", string(first.Body()))
+	s.Equal("2", string(second.Body()))
+	s.Equal("2", string(third.Body()))
+}
+
+func (s *TestRecorderSuite) Test_RecordMissing_ShouldOnlySendUnknownRequests() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer svc.Close()
+
+	path := filepath.Join(s.T().TempDir(), "cassette.json")
+	recorder, err := NewRecorder(path, ModeRecordMissing)
+	s.Require().NoError(err)
+	client := New(svc.URL, WithRecorder(recorder))
+
+	_, err = client.Get(s.ctx, "/known")
+	s.Require().NoError(err)
+	s.Require().NoError(recorder.Save())
+
+	recorder, err = NewRecorder(path, ModeRecordMissing)
+	s.Require().NoError(err)
+	client = New(svc.URL, WithRecorder(recorder))
+
+	// Act
+	known, err1 := client.Get(s.ctx, "/known")
+	unknown, err2 := client.Get(s.ctx, "/unknown")
+
+	// Assert
+	s.NoError(err1)
+	s.NoError(err2)
+	s.Equal("/known", string(known.Body()))
+	s.Equal("/unknown", string(unknown.Body()))
+	s.Equal(int32(2), atomic.LoadInt32(&hits))
+	s.Len(recorder.Cassette().Interactions, 2)
+}
+
+func (s *TestRecorderSuite) Test_MatchBody_ShouldCompareRequestBodies() {
+	// Arrange
+	path := filepath.Join(s.T().TempDir(), "cassette.json")
+	cassette := `{"interactions":[
+		{"request":{"method":"POST","url":"http://localhost:8080/echo","body":"a"},"response":{"status":200,"body":"A"}},
+		{"request":{"method":"POST","url":"http://localhost:8080/echo","body":"b"},"response":{"status":200,"body":"B"}}
+	]}`
+	s.Require().NoError(ioutil.WriteFile(path, []byte(cassette), 0o644))
+
+	recorder, err := NewRecorder(path, ModeReplay, WithMatcher(MatchBody))
+	s.Require().NoError(err)
+	client := New("http://localhost:8080", WithRecorder(recorder))
+
+	// Act
+	response, err := client.Post(s.ctx, "/echo", WithBody([]byte("b")))
+
+	// Assert
+	s.NoError(err)
+	s.Equal("B", string(response.Body()))
+}
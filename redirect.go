@@ -0,0 +1,114 @@
+package gohttpclient
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// RedirectPolicy decides which redirects are followed and which headers travel with them.
+	// A request to another host than the original one is a cross host redirect.
+	RedirectPolicy struct {
+		// Max is the number of redirects followed before the request fails, zero means DEFAULT_MAX_REDIRECTS
+		Max int
+		// Disabled returns the redirect response itself instead of following it
+		Disabled bool
+		// SameHost makes cross host redirects fail
+		SameHost bool
+		// ForwardAuthorization keeps the Authorization header on cross host redirects
+		ForwardAuthorization bool
+		// DropHeaders removes the headers set by WithHeader on cross host redirects
+		DropHeaders bool
+	}
+)
+
+const (
+	DEFAULT_MAX_REDIRECTS = 10
+)
+
+var (
+	ErrTooManyRedirects  = errors.New("too many redirects")
+	ErrCrossHostRedirect = errors.New("redirect to another host is not allowed")
+)
+
+func (p *RedirectPolicy) check(req *http.Request, via []*http.Request, headers map[string]Header) error {
+	if p.Disabled {
+		return http.ErrUseLastResponse
+	}
+
+	max := p.Max
+	if max == 0 {
+		max = DEFAULT_MAX_REDIRECTS
+	}
+
+	if err := checkRedirectCount(via, max); err != nil {
+		return err
+	}
+
+	original := via[0]
+	if req.URL.Host == original.URL.Host {
+		return nil
+	}
+
+	if p.SameHost {
+		return ErrCrossHostRedirect
+	}
+
+	if p.DropHeaders {
+		for key, header := range headers {
+			if !header.IsDefault {
+				req.Header.Del(key)
+			}
+		}
+	}
+
+	// http.Client strips the header itself when the host changes
+	if auth := original.Header.Get("Authorization"); p.ForwardAuthorization && auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	return nil
+}
+
+// checkRedirectCount fails once more than max redirects would be followed, via holds the requests sent so far
+func checkRedirectCount(via []*http.Request, max int) error {
+	if len(via) > max {
+		return errors.Wrapf(ErrTooManyRedirects, "stopped after %d redirects", max)
+	}
+
+	return nil
+}
+
+// checkRedirect returns a http.Client.CheckRedirect func who applies the redirect policy,
+// or the one of the http client when there is none, and records the followed urls in chain
+func (c *Client) checkRedirect(chain *[]*url.URL) func(req *http.Request, via []*http.Request) error {
+	policy, next := c.redirects, c.httpClient.CheckRedirect
+
+	return func(req *http.Request, via []*http.Request) error {
+		var err error
+		switch {
+		case policy != nil:
+			err = policy.check(req, via, c.headers)
+		case next != nil:
+			err = next(req, via)
+		default:
+			err = checkRedirectCount(via, DEFAULT_MAX_REDIRECTS)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if chain != nil {
+			urls := make([]*url.URL, len(via))
+			for i, r := range via {
+				urls[i] = r.URL
+			}
+			*chain = urls
+		}
+
+		return nil
+	}
+}
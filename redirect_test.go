@@ -0,0 +1,191 @@
+package gohttpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestRedirectSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestRedirect(t *testing.T) {
+	suite.Run(t, new(TestRedirectSuite))
+}
+
+func (s *TestRedirectSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+// newRedirectServer redirects /hop/n to /hop/n-1 until /hop/0 answers
+func newRedirectServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+		if n == 0 {
+			w.Write([]byte("arrived"))
+			return
+		}
+
+		http.Redirect(w, r, "/hop/"+strconv.Itoa(n-1), http.StatusFound)
+	}))
+}
+
+func (s *TestRedirectSuite) Test_Redirects_ShouldExposeChain() {
+	// Arrange
+	svc := newRedirectServer()
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/hop/2")
+
+	// Assert
+	s.NoError(err)
+	s.Equal("arrived", string(response.Body()))
+	s.Require().Len(response.Redirects(), 2)
+	s.Equal("/hop/2", response.Redirects()[0].Path)
+	s.Equal("/hop/1", response.Redirects()[1].Path)
+	s.Equal("/hop/0", response.Get().Request.URL.Path)
+	s.Equal("/hop/2", response.Request().URL.Path)
+	s.Equal("/hop/0", response.FinalRequest().URL.Path)
+}
+
+func (s *TestRedirectSuite) Test_WithRedirectPolicy_WhenMaxIsExceeded_ShouldReturnError() {
+	// Arrange
+	svc := newRedirectServer()
+	defer svc.Close()
+
+	client := New(svc.URL, WithRedirectPolicy(RedirectPolicy{Max: 2}))
+
+	// Act
+	_, allowed := client.Get(s.ctx, "/hop/2")
+	response, err := client.Get(s.ctx, "/hop/3")
+
+	// Assert
+	s.NoError(allowed)
+	s.Nil(response)
+	s.True(errors.Is(err, ErrTooManyRedirects))
+}
+
+func (s *TestRedirectSuite) Test_WithRequestRedirectPolicy_WhenDisabled_ShouldReturnRedirect() {
+	// Arrange
+	svc := newRedirectServer()
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/hop/1", WithRequestRedirectPolicy(RedirectPolicy{Disabled: true}))
+	followed, followedErr := client.Get(s.ctx, "/hop/1")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(http.StatusFound, response.Status())
+	s.Equal("/hop/0", response.Headers().Get("Location"))
+	s.Empty(response.Redirects())
+	s.Nil(client.redirects)
+
+	s.NoError(followedErr)
+	s.Equal("arrived", string(followed.Body()))
+}
+
+func (s *TestRedirectSuite) Test_WithRedirectPolicy_WhenSameHost_ShouldRejectOtherHosts() {
+	// Arrange
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer other.Close()
+
+	svc := httptest.NewServer(http.RedirectHandler(other.URL, http.StatusFound))
+	defer svc.Close()
+
+	client := New(svc.URL, WithRedirectPolicy(RedirectPolicy{SameHost: true}))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.True(errors.Is(err, ErrCrossHostRedirect))
+}
+
+func (s *TestRedirectSuite) Test_CrossHostRedirect_ShouldForwardHeadersByPolicy() {
+	// Arrange
+	received := make(chan http.Header, 1)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header
+	}))
+	defer other.Close()
+
+	// 127.0.0.1 and localhost are different hosts for the client
+	svc := httptest.NewServer(http.RedirectHandler(strings.Replace(other.URL, "127.0.0.1", "localhost", 1), http.StatusFound))
+	defer svc.Close()
+
+	cases := map[string]struct {
+		policy        *RedirectPolicy
+		authorization string
+		custom        string
+	}{
+		"default":               {policy: nil, authorization: "", custom: "value"},
+		"forward authorization": {policy: &RedirectPolicy{ForwardAuthorization: true}, authorization: "Bearer token", custom: "value"},
+		"drop headers":          {policy: &RedirectPolicy{DropHeaders: true}, authorization: "", custom: ""},
+	}
+
+	for name, tc := range cases {
+		s.Suite.Run(name, func() {
+			client := New(svc.URL)
+			if tc.policy != nil {
+				client = New(svc.URL, WithRedirectPolicy(*tc.policy))
+			}
+
+			// Act
+			_, err := client.Get(s.ctx, "/",
+				WithHeader("Authorization", "Bearer token"),
+				WithHeader("X-Custom", "value"),
+			)
+
+			// Assert
+			s.NoError(err)
+			header := <-received
+			s.Equal(tc.authorization, header.Get("Authorization"))
+			s.Equal(tc.custom, header.Get("X-Custom"))
+		})
+	}
+}
+
+func (s *TestRedirectSuite) Test_DefaultRedirectLimit_ShouldMatchZeroMaxPolicy() {
+	// Arrange
+	var hits int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		http.Redirect(w, r, "/again", http.StatusFound)
+	}))
+	defer svc.Close()
+
+	clients := map[string]*Client{
+		"without policy": New(svc.URL),
+		"zero max":       New(svc.URL, WithRedirectPolicy(RedirectPolicy{})),
+	}
+
+	for name, client := range clients {
+		s.Suite.Run(name, func() {
+			atomic.StoreInt32(&hits, 0)
+
+			// Act
+			response, err := client.Get(s.ctx, "/")
+
+			// Assert
+			s.Nil(response)
+			s.True(errors.Is(err, ErrTooManyRedirects))
+			s.Equal(int32(DEFAULT_MAX_REDIRECTS+1), atomic.LoadInt32(&hits))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package gohttpclient
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// Request builds a single request step by step, it owns its state so it can be cloned,
+	// inspected and sent many times without touching the Client
+	Request struct {
+		client *Client
+
+		headers    map[string]string
+		query      map[string]string
+		pathParams map[string]string
+		body       []byte
+		timeout    time.Duration
+		opts       []Option
+	}
+)
+
+// R func returns an empty Request builder who is sent by the client
+func (c *Client) R() *Request {
+	return &Request{
+		client:     c,
+		headers:    make(map[string]string),
+		query:      make(map[string]string),
+		pathParams: make(map[string]string),
+	}
+}
+
+func (r *Request) Header(key, value string) *Request {
+	r.headers[key] = value
+	return r
+}
+
+func (r *Request) Query(key, value string) *Request {
+	r.query[key] = value
+	return r
+}
+
+// PathParam replaces {key} in the url given to Do by the escaped value
+func (r *Request) PathParam(key, value string) *Request {
+	r.pathParams[key] = value
+	return r
+}
+
+func (r *Request) Body(body []byte) *Request {
+	r.body = body
+	return r
+}
+
+// Timeout overrides the client timeout for this request
+func (r *Request) Timeout(timeout time.Duration) *Request {
+	r.timeout = timeout
+	return r
+}
+
+// With adds request options, they are applied before the state set by the other builder methods
+func (r *Request) With(opts ...Option) *Request {
+	r.opts = append(r.opts, opts...)
+	return r
+}
+
+// Clone returns a copy of the request who can be changed independently
+func (r *Request) Clone() *Request {
+	clone := *r.client.R()
+	for key, value := range r.headers {
+		clone.headers[key] = value
+	}
+
+	for key, value := range r.query {
+		clone.query[key] = value
+	}
+
+	for key, value := range r.pathParams {
+		clone.pathParams[key] = value
+	}
+
+	clone.body, clone.timeout = r.body, r.timeout
+	clone.opts = append([]Option(nil), r.opts...)
+	return &clone
+}
+
+func (r *Request) GetHeader(key string) string {
+	return r.headers[key]
+}
+
+func (r *Request) GetQuery(key string) string {
+	return r.query[key]
+}
+
+func (r *Request) GetPathParam(key string) string {
+	return r.pathParams[key]
+}
+
+func (r *Request) GetBody() []byte {
+	return r.body
+}
+
+func (r *Request) GetTimeout() time.Duration {
+	return r.timeout
+}
+
+// Options returns the request as options, so it can be sent by any method of the client
+func (r *Request) Options() []Option {
+	opts := append([]Option(nil), r.opts...)
+	for key, value := range r.headers {
+		opts = append(opts, WithHeader(key, value))
+	}
+
+	for key, value := range r.query {
+		opts = append(opts, WithQuery(key, value))
+	}
+
+	if r.body != nil {
+		opts = append(opts, WithBody(r.body))
+	}
+
+	if r.timeout > 0 {
+		opts = append(opts, WithRequestTimeout(r.timeout))
+	}
+
+	return opts
+}
+
+// URL returns endpoint with the path params filled in
+func (r *Request) URL(endpoint string) string {
+	for key, value := range r.pathParams {
+		endpoint = strings.ReplaceAll(endpoint, "{"+key+"}", url.PathEscape(value))
+	}
+
+	return endpoint
+}
+
+// Do sends the request with method to endpoint, relative to the base url of the client
+func (r *Request) Do(ctx context.Context, method, endpoint string) (*Response, error) {
+	return r.client.Do(ctx, method, r.URL(endpoint), r.Options()...)
+}
@@ -0,0 +1,111 @@
+package gohttpclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestRequestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestRequest(t *testing.T) {
+	suite.Run(t, new(TestRequestSuite))
+}
+
+func (s *TestRequestSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+// newEchoServer answers with the method, the url, a header and the body of the request
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write([]byte(r.Method + " " + r.URL.String() + " " + r.Header.Get("X-Tenant") + " " + string(body)))
+	}))
+}
+
+func (s *TestRequestSuite) Test_Do_ShouldSendBuiltRequest() {
+	// Arrange
+	svc := newEchoServer()
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.R().
+		Header("X-Tenant", "acme").
+		Query("expand", "author").
+		PathParam("id", "a b").
+		Body([]byte("body")).
+		Timeout(time.Second).
+		Do(s.ctx, http.MethodPost, "/posts/{id}")
+
+	// Assert
+	s.NoError(err)
+	s.Equal("POST /posts/a%20b?expand=author acme body", string(response.Body()))
+	s.Nil(client.headers)
+	s.Nil(client.query)
+}
+
+func (s *TestRequestSuite) Test_Clone_ShouldNotShareState() {
+	// Arrange
+	client := New("http://localhost:8080")
+	base := client.R().Header("X-Tenant", "acme").Query("page", "1")
+
+	// Act
+	clone := base.Clone().Header("X-Tenant", "other").Query("page", "2").Timeout(time.Second)
+
+	// Assert
+	s.Equal("acme", base.GetHeader("X-Tenant"))
+	s.Equal("1", base.GetQuery("page"))
+	s.Equal(time.Duration(0), base.GetTimeout())
+	s.Equal("other", clone.GetHeader("X-Tenant"))
+	s.Equal("2", clone.GetQuery("page"))
+	s.Equal(time.Second, clone.GetTimeout())
+}
+
+func (s *TestRequestSuite) Test_Request_ShouldInteroperateWithOptions() {
+	// Arrange
+	svc := newEchoServer()
+	defer svc.Close()
+
+	client := New(svc.URL)
+	req := client.R().With(WithHeader("X-Tenant", "option"), WithBody([]byte("option"))).Body([]byte("builder"))
+
+	// Act
+	built, builtErr := req.Do(s.ctx, http.MethodPut, "/")
+	viaVerb, viaVerbErr := client.Put(s.ctx, "/", req.Options()...)
+
+	// Assert
+	s.NoError(builtErr)
+	s.NoError(viaVerbErr)
+	s.Equal("PUT / option builder", string(built.Body()))
+	s.Equal("PUT / option builder", string(viaVerb.Body()))
+}
+
+func (s *TestRequestSuite) Test_Request_ShouldBeReusable() {
+	// Arrange
+	svc := newEchoServer()
+	defer svc.Close()
+
+	client := New(svc.URL)
+	req := client.R().PathParam("id", "1")
+
+	// Act
+	first, firstErr := req.Do(s.ctx, http.MethodGet, "/posts/{id}")
+	second, secondErr := req.Do(s.ctx, http.MethodDelete, "/posts/{id}")
+
+	// Assert
+	s.NoError(firstErr)
+	s.NoError(secondErr)
+	s.Equal("GET /posts/1  ", string(first.Body()))
+	s.Equal("DELETE /posts/1  ", string(second.Body()))
+}
@@ -1,14 +1,27 @@
 package gohttpclient
 
 import (
-	"encoding/json"
+	"bytes"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
 )
 
 type (
 	Response struct {
-		res  *http.Response
-		body []byte
+		req       *http.Request
+		res       *http.Response
+		body      []byte
+		hedges    int
+		redirects []*url.URL
+
+		encoding       string
+		compressedSize int64
+
+		duration time.Duration
 	}
 )
 
@@ -16,8 +29,61 @@ func (r *Response) Body() []byte {
 	return r.body
 }
 
+// Unmarshal func decodes a JSON body into v
 func (r *Response) Unmarshal(v any) error {
-	return json.Unmarshal(r.body, &v)
+	codec, err := CodecFor(MediaTypeJSON)
+	if err != nil {
+		return err
+	}
+
+	return codec.Unmarshal(r.body, v)
+}
+
+// UnmarshalXml func decodes an XML body into v, the charset of the Content-Type or else
+// of the XML declaration is converted to UTF-8. It is not named UnmarshalXML, which would
+// clash with the xml.Unmarshaler interface.
+func (r *Response) UnmarshalXml(v any) error {
+	return r.decode(MediaTypeXML, v)
+}
+
+// Decode func decodes the body into v with the codec registered for the Content-Type of the response,
+// a response without Content-Type is decoded as JSON
+func (r *Response) Decode(v any) error {
+	mediaType := r.contentType()
+	if mediaType == "" {
+		mediaType = MediaTypeJSON
+	}
+
+	return r.decode(mediaType, v)
+}
+
+// decode decodes the body with the codec of mediaType, a body in another charset than UTF-8
+// is converted before the codec sees it
+func (r *Response) decode(mediaType string, v any) error {
+	codec, err := CodecFor(mediaType)
+	if err != nil {
+		return err
+	}
+
+	charset := contentCharset(r.contentType())
+	if cu, ok := codec.(charsetUnmarshaler); ok {
+		return cu.unmarshalCharset(r.body, charset, v)
+	}
+
+	body, err := toUTF8(charset, r.body)
+	if err != nil {
+		return err
+	}
+
+	return codec.Unmarshal(body, v)
+}
+
+func (r *Response) contentType() string {
+	if r.res == nil {
+		return ""
+	}
+
+	return r.res.Header.Get("Content-Type")
 }
 
 func (r *Response) Status() int {
@@ -36,6 +102,115 @@ func (r *Response) Ok() bool {
 	return r.res.StatusCode >= 200 && r.res.StatusCode <= 299
 }
 
+func (r *Response) IsRedirect() bool {
+	return r.res.StatusCode >= 300 && r.res.StatusCode <= 399
+}
+
+func (r *Response) IsClientError() bool {
+	return r.res.StatusCode >= 400 && r.res.StatusCode <= 499
+}
+
+func (r *Response) IsServerError() bool {
+	return r.res.StatusCode >= 500 && r.res.StatusCode <= 599
+}
+
+// String func returns the body converted to UTF-8 from the charset of the Content-Type,
+// a body in an unsupported charset is returned as it is
+func (r *Response) String() string {
+	body, err := toUTF8(contentCharset(r.contentType()), r.body)
+	if err != nil {
+		return string(r.body)
+	}
+
+	return string(body)
+}
+
+// ContentType func returns the media type of the Content-Type header without its parameters
+func (r *Response) ContentType() string {
+	mediaType, _, err := mime.ParseMediaType(r.contentType())
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
+// ContentLength func returns the Content-Length of the response, or the size of the body when
+// the header was missing or removed by decompression
+func (r *Response) ContentLength() int64 {
+	if r.res.ContentLength >= 0 {
+		return r.res.ContentLength
+	}
+
+	return int64(len(r.body))
+}
+
+// Location func returns the Location header resolved against the request url
+func (r *Response) Location() (*url.URL, error) {
+	return r.res.Location()
+}
+
+// Duration func returns how long it took from sending the request until the body was read
+func (r *Response) Duration() time.Duration {
+	return r.duration
+}
+
+// Request func returns the request as it was sent first, before any redirect was followed
+func (r *Response) Request() *http.Request {
+	if r.req == nil {
+		return r.res.Request
+	}
+
+	return r.req
+}
+
+// FinalRequest func returns the request who got this response, the last one when redirects were followed
+func (r *Response) FinalRequest() *http.Request {
+	return r.res.Request
+}
+
+func (r *Response) Proto() string {
+	return r.res.Proto
+}
+
+func (r *Response) Trailer() http.Header {
+	return r.res.Trailer
+}
+
+// Dump func returns the response as it would appear on the wire, for debugging
+func (r *Response) Dump() ([]byte, error) {
+	res := *r.res
+	res.Body = ioutil.NopCloser(bytes.NewReader(r.body))
+	res.ContentLength = int64(len(r.body))
+	res.TransferEncoding = nil
+	return httputil.DumpResponse(&res, true)
+}
+
 func (r *Response) Get() *http.Response {
 	return r.res
 }
+
+// Hedges func returns how many extra requests were fired by WithHedging before a response won
+func (r *Response) Hedges() int {
+	return r.hedges
+}
+
+// Redirects func returns the urls who answered with a followed redirect, in the order they were requested.
+// The url of the final response is FinalRequest().URL.
+func (r *Response) Redirects() []*url.URL {
+	return r.redirects
+}
+
+// ContentEncoding func returns the Content-Encoding the body was decompressed from, empty when it came uncompressed
+func (r *Response) ContentEncoding() string {
+	return r.encoding
+}
+
+// CompressedSize func returns the size of the body as it was received, before decompression
+func (r *Response) CompressedSize() int64 {
+	if r.encoding == "" {
+		return int64(len(r.body))
+	}
+
+	return r.compressedSize
+}
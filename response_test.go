@@ -5,6 +5,7 @@ import (
 	"context"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -31,7 +32,7 @@ func (s *TestResponseSuite) Test_Body_ShouldRunSuccesfully() {
 	}
 
 	// Act
-	resp := Response{res, body}
+	resp := Response{res: res, body: body}
 
 	// Assert
 	s.Equal(body, resp.Body())
@@ -43,7 +44,7 @@ func (s *TestResponseSuite) Test_Unmarshal_ShouldRunSuccesfully() {
 	res := &http.Response{}
 
 	// Act
-	resp := Response{res, body}
+	resp := Response{res: res, body: body}
 
 	// Assert
 	var response map[string]interface{}
@@ -72,7 +73,7 @@ func (s *TestResponseSuite) Test_Unmarshal_WhenUnMarshalReturnsError_ShouldRetur
 	res := &http.Response{}
 
 	// Act
-	resp := Response{res, body}
+	resp := Response{res: res, body: body}
 
 	// Assert
 	var response map[string]interface{}
@@ -160,3 +161,81 @@ func (s *TestResponseSuite) Test_Get_ShouldRunSuccesfully() {
 	// Assert
 	s.Equal(resp.res, res)
 }
+
+func (s *TestResponseSuite) Test_StatusClasses_ShouldRunSuccesfully() {
+	// Arrange
+	cases := map[int][3]bool{
+		200: {false, false, false},
+		301: {true, false, false},
+		404: {false, true, false},
+		503: {false, false, true},
+	}
+
+	for status, expected := range cases {
+		resp := Response{res: &http.Response{StatusCode: status}}
+
+		// Act
+		classes := [3]bool{resp.IsRedirect(), resp.IsClientError(), resp.IsServerError()}
+
+		// Assert
+		s.Equal(expected, classes, status)
+	}
+}
+
+func (s *TestResponseSuite) Test_String_ShouldDecodeCharset() {
+	// Arrange
+	resp := Response{
+		res: &http.Response{
+			Header: http.Header{"Content-Type": []string{"text/plain; charset=ISO-8859-1"}},
+		},
+		body: []byte("Zo\xeb"),
+	}
+
+	// Act
+	body := resp.String()
+
+	// Assert
+	s.Equal("Zoë", body)
+	s.Equal("text/plain", resp.ContentType())
+}
+
+func (s *TestResponseSuite) Test_ContentLength_ShouldFallBackToBodySize() {
+	// Arrange
+	withHeader := Response{res: &http.Response{ContentLength: 1024}}
+	withoutHeader := Response{res: &http.Response{ContentLength: -1}, body: []byte("body")}
+
+	// Act & Assert
+	s.Equal(int64(1024), withHeader.ContentLength())
+	s.Equal(int64(4), withoutHeader.ContentLength())
+}
+
+func (s *TestResponseSuite) Test_Accessors_ShouldRunSuccesfully() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Checksum")
+		w.Header().Set("Location", "/posts/2")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+		w.Header().Set("X-Checksum", "abc")
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	resp, err := client.Post(s.ctx, "/posts")
+	s.Require().NoError(err)
+	location, locationErr := resp.Location()
+	dump, dumpErr := resp.Dump()
+
+	// Assert
+	s.NoError(locationErr)
+	s.Equal(svc.URL+"/posts/2", location.String())
+	s.Equal(http.MethodPost, resp.Request().Method)
+	s.Equal("HTTP/1.1", resp.Proto())
+	s.Equal("abc", resp.Trailer().Get("X-Checksum"))
+	s.True(resp.Duration() > 0)
+	s.NoError(dumpErr)
+	s.Contains(string(dump), "HTTP/1.1 201 Created\r\n")
+	s.Contains(string(dump), "\r\n\r\ncreated")
+}
@@ -0,0 +1,261 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Event is a single server-sent event
+	Event struct {
+		ID    string
+		Event string
+		Data  string
+		Retry time.Duration
+	}
+
+	// EventStream delivers the events of a SSE endpoint and reconnects when the connection drops
+	EventStream struct {
+		client     *Client
+		httpClient *http.Client
+		endpoint   string
+
+		events      chan Event
+		cancel      context.CancelFunc
+		retry       time.Duration
+		lastEventID string
+
+		mu  sync.Mutex
+		err error
+	}
+)
+
+const (
+	DEFAULT_SSE_RETRY = 3 * time.Second
+)
+
+var (
+	// ErrStreamClosed is returned when the server answers with 204 No Content to tell the client to stop
+	ErrStreamClosed = errors.New("event stream closed by server")
+)
+
+// SSE func connects to a server-sent events endpoint. The stream is not bound to the client timeout,
+// it runs until ctx is cancelled, Close is called or the server refuses a reconnection.
+func (c *Client) SSE(ctx context.Context, endpoint string, opts ...Option) (*EventStream, error) {
+	rc := c.withOpts(opts...)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &EventStream{
+		client:     rc,
+		httpClient: rc.streamingClient(),
+		endpoint:   endpoint,
+		events:     make(chan Event),
+		cancel:     cancel,
+		retry:      DEFAULT_SSE_RETRY,
+	}
+
+	body, _, err := stream.connect(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go stream.run(ctx, body)
+	return stream, nil
+}
+
+// Events func returns the channel of events, it is closed when the stream stops
+func (s *EventStream) Events() <-chan Event {
+	return s.events
+}
+
+// Err func returns the reason the stream stopped, it is only set after the events channel is closed
+func (s *EventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Close func stops the stream and its reconnection attempts
+func (s *EventStream) Close() {
+	s.cancel()
+}
+
+// connect opens the stream, refused is true when the server answered and retrying makes no sense
+func (s *EventStream) connect(ctx context.Context) (body io.ReadCloser, refused bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.client.baseUrl+s.endpoint, nil)
+	if err != nil {
+		return nil, true, err
+	}
+
+	req, err = s.client.prepareReq(req)
+	if err != nil {
+		return nil, true, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to send request")
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNoContent:
+		res.Body.Close()
+		return nil, true, ErrStreamClosed
+	case res.StatusCode != http.StatusOK:
+		res.Body.Close()
+		return nil, true, errors.Errorf("unexpected status code %d for event stream", res.StatusCode)
+	}
+
+	return res.Body, false, nil
+}
+
+func (s *EventStream) run(ctx context.Context, body io.ReadCloser) {
+	defer close(s.events)
+	defer s.cancel()
+
+	for {
+		// a dropped connection is not an error for the stream, it just reconnects
+		_ = s.read(ctx, body)
+		body.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.setErr(ctx.Err())
+				return
+			case <-time.After(s.retry):
+			}
+
+			next, refused, err := s.connect(ctx)
+			if err == nil {
+				body = next
+				break
+			}
+
+			if refused {
+				s.setErr(err)
+				return
+			}
+		}
+	}
+}
+
+func (s *EventStream) read(ctx context.Context, body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+	scanner.Split(scanLines())
+
+	var (
+		event Event
+		data  strings.Builder
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() == 0 {
+				event = Event{}
+				continue
+			}
+
+			event.ID = s.lastEventID
+			event.Data = strings.TrimSuffix(data.String(), "\n")
+			if event.Event == "" {
+				event.Event = "message"
+			}
+
+			select {
+			case s.events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			event = Event{}
+			data.Reset()
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				s.retry = time.Duration(ms) * time.Millisecond
+				event.Retry = s.retry
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (s *EventStream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// scanLines splits on \r\n, \n and a lone \r as the event stream format allows all three.
+// A \r is a line end on its own, so the split never waits for the next read to see if \n follows.
+func scanLines() bufio.SplitFunc {
+	var afterCR bool
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if afterCR && len(data) > 0 {
+			afterCR = false
+			if data[0] == '\n' {
+				return 1, nil, nil
+			}
+		}
+
+		if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+			if data[i] == '\n' {
+				return i + 1, data[:i], nil
+			}
+
+			if i+1 < len(data) && data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+
+			afterCR = i+1 == len(data)
+			return i + 1, data[:i], nil
+		}
+
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+
+		return 0, nil, nil
+	}
+}
@@ -0,0 +1,202 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSSESuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestSSE(t *testing.T) {
+	suite.Run(t, new(TestSSESuite))
+}
+
+func (s *TestSSESuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestSSESuite) Test_SSE_ShouldParseEvents() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("text/event-stream", r.Header.Get("Accept"))
+		s.Equal("value", r.Header.Get("key"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": comment\n\n")
+		fmt.Fprint(w, "id: 1\nevent: created\ndata: first\ndata: second\n\n")
+		fmt.Fprint(w, "data:plain\r\n\r\n")
+		fmt.Fprint(w, "retry: 10\rdata: third\r\r")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	stream, err := client.SSE(s.ctx, "/events", WithHeader("key", "value"))
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	events := []Event{<-stream.Events(), <-stream.Events(), <-stream.Events()}
+
+	// Assert
+	s.Equal(Event{ID: "1", Event: "created", Data: "first\nsecond"}, events[0])
+	s.Equal(Event{ID: "1", Event: "message", Data: "plain"}, events[1])
+	s.Equal(Event{ID: "1", Event: "message", Data: "third", Retry: 10 * time.Millisecond}, events[2])
+}
+
+func (s *TestSSESuite) Test_SSE_WhenConnectionDrops_ShouldReconnectWithLastEventID() {
+	// Arrange
+	var connections int32
+	lastEventIDs := make(chan string, 2)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs <- r.Header.Get("Last-Event-ID")
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			fmt.Fprint(w, "retry: 5\nid: 41\ndata: before\n\n")
+		case 2:
+			fmt.Fprint(w, "id: 42\ndata: after\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	stream, err := client.SSE(s.ctx, "/events")
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	first := <-stream.Events()
+	second := <-stream.Events()
+
+	// Assert
+	s.Equal("before", first.Data)
+	s.Equal("after", second.Data)
+	s.Equal("42", second.ID)
+	s.Equal("", <-lastEventIDs)
+	s.Equal("41", <-lastEventIDs)
+}
+
+func (s *TestSSESuite) Test_SSE_ShouldNotUseClientTimeout() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, "data: late\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithTimeout(20*time.Millisecond))
+
+	// Act
+	stream, err := client.SSE(s.ctx, "/events")
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	event, ok := <-stream.Events()
+
+	// Assert
+	s.True(ok)
+	s.Equal("late", event.Data)
+}
+
+func (s *TestSSESuite) Test_SSE_WhenServerReturnsNoContent_ShouldStop() {
+	// Arrange
+	var connections int32
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&connections, 1) > 1 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		fmt.Fprint(w, "retry: 1\ndata: only\n\n")
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	stream, err := client.SSE(s.ctx, "/events")
+	s.Require().NoError(err)
+
+	var events []Event
+	for event := range stream.Events() {
+		events = append(events, event)
+	}
+
+	// Assert
+	s.Len(events, 1)
+	s.Equal(ErrStreamClosed, stream.Err())
+}
+
+func (s *TestSSESuite) Test_SSE_WhenStatusIsNotOk_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	stream, err := client.SSE(s.ctx, "/events")
+
+	// Assert
+	s.Nil(stream)
+	s.Error(err)
+}
+
+func (s *TestSSESuite) Test_Close_ShouldCloseEvents() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	stream, err := client.SSE(s.ctx, "/events")
+	s.Require().NoError(err)
+
+	// Act
+	stream.Close()
+	_, ok := <-stream.Events()
+
+	// Assert
+	s.False(ok)
+	s.Equal(context.Canceled, stream.Err())
+}
+
+func (s *TestSSESuite) Test_scanLines_ShouldSplitEveryLineEnding() {
+	// Arrange
+	scanner := bufio.NewScanner(io.MultiReader(strings.NewReader("a\nb\r"), strings.NewReader("\nc\rd")))
+	scanner.Split(scanLines())
+
+	// Act
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	// Assert
+	s.Equal([]string{"a", "b", "c", "d"}, lines)
+}
@@ -0,0 +1,169 @@
+package gohttpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// TimeoutPhase names the part of a request whose timeout expired
+	TimeoutPhase string
+
+	// TimeoutError is returned when a request ran out of time, Phase says which budget expired
+	TimeoutError struct {
+		Phase   TimeoutPhase
+		Timeout time.Duration
+		Err     error
+	}
+
+	phaseTimeouts struct {
+		connect  time.Duration
+		header   time.Duration
+		idleRead time.Duration
+	}
+
+	// phaseTimer cancels a request when the timeout of its current phase expires
+	phaseTimer struct {
+		cancel context.CancelFunc
+
+		mu      sync.Mutex
+		timer   *time.Timer
+		expired *TimeoutError
+	}
+
+	// idleReader restarts the idle read timeout before every read of the response body
+	idleReader struct {
+		r       io.Reader
+		timer   *phaseTimer
+		timeout time.Duration
+	}
+)
+
+const (
+	PhaseConnect TimeoutPhase = "connect"
+	PhaseHeader  TimeoutPhase = "header"
+	PhaseBody    TimeoutPhase = "body"
+	PhaseTotal   TimeoutPhase = "total"
+)
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timeout of %s exceeded", e.Phase, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is func keeps errors.Is(err, context.DeadlineExceeded) working for every phase
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// start arms the timer for phase, a zero timeout leaves the phase unbounded
+func (t *phaseTimer) start(phase TimeoutPhase, timeout time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+
+	if timeout <= 0 || t.expired != nil {
+		return
+	}
+
+	t.timer = time.AfterFunc(timeout, func() {
+		t.mu.Lock()
+		if t.expired == nil {
+			t.expired = &TimeoutError{Phase: phase, Timeout: timeout}
+		}
+		t.mu.Unlock()
+		t.cancel()
+	})
+}
+
+func (t *phaseTimer) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+func (t *phaseTimer) err() *TimeoutError {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.expired
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	r.timer.start(PhaseBody, r.timeout)
+	n, err := r.r.Read(p)
+	r.timer.stop()
+	return n, err
+}
+
+// withPhases returns a context who is cancelled when the connect or header timeout expires
+func (c *Client) withPhases(ctx context.Context) (context.Context, *phaseTimer, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	timer := &phaseTimer{cancel: cancel}
+
+	if c.timeouts.connect > 0 || c.timeouts.header > 0 {
+		ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+			GetConn: func(string) {
+				timer.start(PhaseConnect, c.timeouts.connect)
+			},
+			GotConn: func(httptrace.GotConnInfo) {
+				timer.stop()
+			},
+			WroteRequest: func(httptrace.WroteRequestInfo) {
+				timer.start(PhaseHeader, c.timeouts.header)
+			},
+		})
+	}
+
+	return ctx, timer, func() {
+		timer.stop()
+		cancel()
+	}
+}
+
+// phaseErr replaces err by a TimeoutError when the timeout of a phase expired
+func phaseErr(timer *phaseTimer, err error) error {
+	expired := timer.err()
+	if expired == nil {
+		return err
+	}
+
+	return &TimeoutError{Phase: expired.Phase, Timeout: expired.Timeout, Err: err}
+}
+
+// totalErr replaces err by a TimeoutError when the request timeout expired, a deadline
+// of the caller's context is left as it is
+// requestTimeout returns the deadline of a whole request, the timeout of a http client given by
+// WithCustomHttpClient still applies when it is the shorter one
+func (c *Client) requestTimeout() time.Duration {
+	if c.customClient && c.httpClient.Timeout > 0 && c.httpClient.Timeout < c.timeout {
+		return c.httpClient.Timeout
+	}
+
+	return c.timeout
+}
+
+func (c *Client) totalErr(ctx, reqCtx context.Context, err error) error {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) || ctx.Err() != nil || reqCtx.Err() != context.DeadlineExceeded {
+		return err
+	}
+
+	return &TimeoutError{Phase: PhaseTotal, Timeout: c.requestTimeout(), Err: err}
+}
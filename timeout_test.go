@@ -0,0 +1,185 @@
+package gohttpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestTimeoutSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestTimeout(t *testing.T) {
+	suite.Run(t, new(TestTimeoutSuite))
+}
+
+func (s *TestTimeoutSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestTimeoutSuite) Test_WithRequestTimeout_ShouldOverrideClientTimeout() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithTimeout(20*time.Millisecond))
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithRequestTimeout(time.Second))
+	_, sharedErr := client.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(err)
+	s.True(response.Ok())
+	s.Equal(20*time.Millisecond, client.timeout)
+	s.Equal(20*time.Millisecond, client.httpClient.Timeout)
+
+	var timeoutErr *TimeoutError
+	s.Require().True(errors.As(sharedErr, &timeoutErr))
+	s.Equal(PhaseTotal, timeoutErr.Phase)
+	s.Equal(20*time.Millisecond, timeoutErr.Timeout)
+	s.True(errors.Is(sharedErr, context.DeadlineExceeded))
+}
+
+func (s *TestTimeoutSuite) Test_WithCustomHttpClient_ShouldApplyItsTimeout() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithCustomHttpClient(&http.Client{Timeout: 50 * time.Millisecond}))
+
+	// Act
+	start := time.Now()
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.Less(int64(time.Since(start)), int64(400*time.Millisecond))
+
+	var timeoutErr *TimeoutError
+	s.Require().True(errors.As(err, &timeoutErr))
+	s.Equal(PhaseTotal, timeoutErr.Phase)
+	s.Equal(50*time.Millisecond, timeoutErr.Timeout)
+}
+
+func (s *TestTimeoutSuite) Test_WithHeaderTimeout_ShouldReturnHeaderPhase() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithHeaderTimeout(10*time.Millisecond))
+
+	// Assert
+	s.Nil(response)
+
+	var timeoutErr *TimeoutError
+	s.Require().True(errors.As(err, &timeoutErr))
+	s.Equal(PhaseHeader, timeoutErr.Phase)
+	s.EqualError(err, "header timeout of 10ms exceeded")
+}
+
+func (s *TestTimeoutSuite) Test_WithIdleReadTimeout_ShouldReturnBodyPhase() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("second"))
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithIdleReadTimeout(10*time.Millisecond))
+
+	// Assert
+	s.Nil(response)
+
+	var timeoutErr *TimeoutError
+	s.Require().True(errors.As(err, &timeoutErr))
+	s.Equal(PhaseBody, timeoutErr.Phase)
+}
+
+func (s *TestTimeoutSuite) Test_WithIdleReadTimeout_WhenDataKeepsComing_ShouldSucceed() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 5; i++ {
+			w.Write([]byte("."))
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond)
+		}
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithIdleReadTimeout(time.Second))
+
+	// Assert
+	s.NoError(err)
+	s.Equal(".....", string(response.Body()))
+}
+
+func (s *TestTimeoutSuite) Test_WithConnectTimeout_ShouldReturnConnectPhase() {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	client := New("http://"+listener.Addr().String(), WithDialTimeout(time.Second))
+	transport := client.currentTransport()
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		<-ctx.Done()
+		return dial(ctx, network, addr)
+	}
+
+	// Act
+	response, err := client.Get(s.ctx, "/", WithConnectTimeout(10*time.Millisecond))
+
+	// Assert
+	s.Nil(response)
+
+	var timeoutErr *TimeoutError
+	s.Require().True(errors.As(err, &timeoutErr))
+	s.Equal(PhaseConnect, timeoutErr.Phase)
+}
+
+func (s *TestTimeoutSuite) Test_CallerDeadline_ShouldNotBeReportedAsTimeoutError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := client.Get(ctx, "/")
+
+	// Assert
+	var timeoutErr *TimeoutError
+	s.Error(err)
+	s.False(errors.As(err, &timeoutErr))
+}
@@ -0,0 +1,121 @@
+package gohttpclient
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// certReloader serves the client certificate and loads it again when the files change on disk
+	certReloader struct {
+		certFile string
+		keyFile  string
+
+		mu      sync.Mutex
+		cert    *tls.Certificate
+		certMod time.Time
+		keyMod  time.Time
+	}
+)
+
+var (
+	ErrCertificatePinMismatch = errors.New("no certificate of the server matches a pinned public key")
+)
+
+// tlsConfig returns the TLS config of the client transport, options who need it fail the client
+// when the http client uses a custom http.RoundTripper
+func (c *Client) tlsConfig() *tls.Config {
+	transport := c.transport()
+	if transport == nil {
+		c.err = errors.New("tls options need the http client to use a *http.Transport")
+		return nil
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+
+	return transport.TLSClientConfig
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetClientCertificate func is used as tls.Config.GetClientCertificate
+func (r *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.load()
+}
+
+func (r *certReloader) load() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read client certificate")
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read client key")
+	}
+
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return r.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		// keep serving the previous pair while a rotation is half written
+		if r.cert != nil {
+			return r.cert, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to load client certificate")
+	}
+
+	r.cert, r.certMod, r.keyMod = &cert, certInfo.ModTime(), keyInfo.ModTime()
+	return r.cert, nil
+}
+
+// verifyPins returns a tls.Config.VerifyConnection func who accepts the connection when
+// the public key of any certificate in a verified chain is pinned. The certificates the server
+// sent are not trusted as they are, anyone can append a pinned certificate to them.
+func verifyPins(pins []string) func(tls.ConnectionState) error {
+	pinned := make(map[string]bool, len(pins))
+	for _, pin := range pins {
+		pinned[strings.TrimPrefix(pin, "sha256/")] = true
+	}
+
+	return func(cs tls.ConnectionState) error {
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				if pinned[SPKIHash(cert.RawSubjectPublicKeyInfo)] {
+					return nil
+				}
+			}
+		}
+
+		return ErrCertificatePinMismatch
+	}
+}
+
+// SPKIHash func returns the base64 encoded sha256 of a DER encoded SubjectPublicKeyInfo,
+// the format WithPinnedPublicKeys expects
+func SPKIHash(spki []byte) string {
+	sum := sha256.Sum256(spki)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,255 @@
+package gohttpclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestTLSSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestTLS(t *testing.T) {
+	suite.Run(t, new(TestTLSSuite))
+}
+
+func (s *TestTLSSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+// writeClientCertificate writes a self signed certificate with the common name to certFile and keyFile
+func (s *TestTLSSuite) writeClientCertificate(certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+
+	s.Require().NoError(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	s.Require().NoError(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+}
+
+// createCertificate signs template by parent with parentKey, a nil parent makes it self signed
+func (s *TestTLSSuite) createCertificate(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore, template.NotAfter = time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	s.Require().NoError(err)
+
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return cert, key
+}
+
+func (s *TestTLSSuite) serverPEM(svc *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svc.Certificate().Raw})
+}
+
+func (s *TestTLSSuite) Test_WithRootCAs_ShouldTrustServer() {
+	// Arrange
+	svc := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithRootCAs(s.serverPEM(svc)), WithTimeout(time.Second))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(err)
+	s.True(response.Ok())
+	s.Equal(time.Second, client.httpClient.Timeout)
+}
+
+func (s *TestTLSSuite) Test_WithoutRootCAs_ShouldRejectServer() {
+	// Arrange
+	svc := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *TestTLSSuite) Test_WithRootCAs_WhenPEMIsInvalid_ShouldReturnError() {
+	// Arrange
+	client := New("https://localhost:8443", WithRootCAs([]byte("not a certificate")))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.EqualError(err, "no certificate found in the root CA PEM")
+}
+
+func (s *TestTLSSuite) Test_WithClientCertificate_ShouldReloadFromDisk() {
+	// Arrange
+	commonNames := make(chan string, 2)
+	svc := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commonNames <- r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	svc.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	svc.Config.SetKeepAlivesEnabled(false)
+	svc.StartTLS()
+	defer svc.Close()
+
+	dir := s.T().TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	s.writeClientCertificate(certFile, keyFile, "first")
+
+	client := New(svc.URL, WithRootCAs(s.serverPEM(svc)), WithClientCertificate(certFile, keyFile))
+
+	// Act
+	_, firstErr := client.Get(s.ctx, "/")
+
+	s.writeClientCertificate(certFile, keyFile, "second")
+	later := time.Now().Add(time.Minute)
+	s.Require().NoError(os.Chtimes(certFile, later, later))
+	s.Require().NoError(os.Chtimes(keyFile, later, later))
+
+	_, secondErr := client.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(firstErr)
+	s.NoError(secondErr)
+	s.Equal("first", <-commonNames)
+	s.Equal("second", <-commonNames)
+}
+
+func (s *TestTLSSuite) Test_WithClientCertificate_WhenFilesAreMissing_ShouldReturnError() {
+	// Arrange
+	dir := s.T().TempDir()
+	client := New("https://localhost:8443", WithClientCertificate(filepath.Join(dir, "a.crt"), filepath.Join(dir, "a.key")))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *TestTLSSuite) Test_WithMinTLSVersion_ShouldRejectOlderServers() {
+	// Arrange
+	svc := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	svc.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	svc.StartTLS()
+	defer svc.Close()
+
+	client := New(svc.URL, WithRootCAs(s.serverPEM(svc)), WithMinTLSVersion(tls.VersionTLS13))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *TestTLSSuite) Test_WithPinnedPublicKeys_ShouldVerifyPins() {
+	// Arrange
+	svc := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svc.Close()
+
+	pin := "sha256/" + SPKIHash(svc.Certificate().RawSubjectPublicKeyInfo)
+	pinned := New(svc.URL, WithRootCAs(s.serverPEM(svc)), WithPinnedPublicKeys(pin))
+	mismatched := New(svc.URL, WithRootCAs(s.serverPEM(svc)), WithPinnedPublicKeys(SPKIHash([]byte("other"))))
+
+	// Act
+	_, pinnedErr := pinned.Get(s.ctx, "/")
+	_, mismatchedErr := mismatched.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(pinnedErr)
+	s.Error(mismatchedErr)
+	s.Contains(mismatchedErr.Error(), ErrCertificatePinMismatch.Error())
+}
+
+func (s *TestTLSSuite) Test_WithPinnedPublicKeys_WhenPinnedCertificateIsNotInVerifiedChain_ShouldReject() {
+	// Arrange
+	ca := &x509.Certificate{Subject: pkix.Name{CommonName: "ca"}, IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}
+	ca, caKey := s.createCertificate(ca, nil, nil)
+	leaf, leafKey := s.createCertificate(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: "leaf"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	pinnedCA, _ := s.createCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "pinned"}, IsCA: true, BasicConstraintsValid: true}, nil, nil)
+
+	svc := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	svc.TLS = &tls.Config{Certificates: []tls.Certificate{{
+		// the pinned certificate is sent along the valid chain without having signed it
+		Certificate: [][]byte{leaf.Raw, pinnedCA.Raw},
+		PrivateKey:  leafKey,
+	}}}
+	svc.StartTLS()
+	defer svc.Close()
+
+	roots := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	unpinned := New(svc.URL, WithRootCAs(roots))
+	pinned := New(svc.URL, WithRootCAs(roots), WithPinnedPublicKeys(SPKIHash(pinnedCA.RawSubjectPublicKeyInfo)))
+
+	// Act
+	_, unpinnedErr := unpinned.Get(s.ctx, "/")
+	response, pinnedErr := pinned.Get(s.ctx, "/")
+
+	// Assert
+	s.NoError(unpinnedErr)
+	s.Nil(response)
+	s.Error(pinnedErr)
+	s.Contains(pinnedErr.Error(), ErrCertificatePinMismatch.Error())
+}
+
+func (s *TestTLSSuite) Test_TLSOptions_WhenTransportIsCustom_ShouldReturnError() {
+	// Arrange
+	httpClient := &http.Client{Transport: http.NewFileTransport(http.Dir("."))}
+	client := New("https://localhost:8443", WithCustomHttpClient(httpClient), WithMinTLSVersion(tls.VersionTLS12))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.Error(err)
+}
@@ -0,0 +1,75 @@
+package gohttpclient
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DEFAULT_DIAL_TIMEOUT = 30 * time.Second
+	DEFAULT_KEEP_ALIVE   = 30 * time.Second
+)
+
+// Close func releases the idle connections of the client
+func (c *Client) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// currentTransport returns the *http.Transport requests go through, nil for custom round trippers
+func (c *Client) currentTransport() *http.Transport {
+	rt := c.httpClient.Transport
+	if recording, ok := rt.(*recordingTransport); ok {
+		rt = recording.transport
+	}
+
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	transport, _ := rt.(*http.Transport)
+	return transport
+}
+
+// transport returns a *http.Transport the client may change. http.DefaultTransport is shared
+// by every client in the process, so it is cloned the first time an option needs to change it.
+func (c *Client) transport() *http.Transport {
+	own := func(rt http.RoundTripper) (*http.Transport, bool) {
+		if rt == nil || rt == http.DefaultTransport {
+			return http.DefaultTransport.(*http.Transport).Clone(), true
+		}
+
+		transport, ok := rt.(*http.Transport)
+		return transport, ok
+	}
+
+	if recording, ok := c.httpClient.Transport.(*recordingTransport); ok {
+		transport, ok := own(recording.transport)
+		if ok {
+			recording.transport = transport
+		}
+		return transport
+	}
+
+	transport, ok := own(c.httpClient.Transport)
+	if ok {
+		c.httpClient.Transport = transport
+	}
+	return transport
+}
+
+// dialer returns the dialer of the client transport, created with the http.DefaultTransport defaults
+func (c *Client) dialer() *net.Dialer {
+	transport := c.transport()
+	if transport == nil {
+		return nil
+	}
+
+	if c.netDialer == nil {
+		c.netDialer = &net.Dialer{Timeout: DEFAULT_DIAL_TIMEOUT, KeepAlive: DEFAULT_KEEP_ALIVE}
+	}
+
+	transport.DialContext = c.netDialer.DialContext
+	return c.netDialer
+}
@@ -0,0 +1,131 @@
+package gohttpclient
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestTransportSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestTransport(t *testing.T) {
+	suite.Run(t, new(TestTransportSuite))
+}
+
+func (s *TestTransportSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestTransportSuite) Test_New_ShouldNotShareTransport() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+
+	// Act
+	first, second := New(baseUrl), New(baseUrl)
+
+	// Assert
+	s.True(first.httpClient.Transport != http.DefaultTransport)
+	s.True(first.httpClient.Transport != second.httpClient.Transport)
+}
+
+func (s *TestTransportSuite) Test_TransportOptions_ShouldRunSuccesfully() {
+	// Arrange
+	baseUrl := "http://localhost:8080"
+
+	// Act
+	client := New(baseUrl,
+		WithMaxIdleConnsPerHost(64),
+		WithIdleConnTimeout(time.Minute),
+		WithDialTimeout(2*time.Second),
+		WithKeepAlive(15*time.Second),
+		WithTLSHandshakeTimeout(3*time.Second),
+		WithResponseHeaderTimeout(4*time.Second),
+	)
+
+	// Assert
+	transport := client.currentTransport()
+	s.Equal(64, transport.MaxIdleConnsPerHost)
+	s.Equal(time.Minute, transport.IdleConnTimeout)
+	s.Equal(3*time.Second, transport.TLSHandshakeTimeout)
+	s.Equal(4*time.Second, transport.ResponseHeaderTimeout)
+	s.Equal(2*time.Second, client.netDialer.Timeout)
+	s.Equal(15*time.Second, client.netDialer.KeepAlive)
+	s.NotNil(transport.DialContext)
+}
+
+func (s *TestTransportSuite) Test_WithResponseHeaderTimeout_ShouldFailSlowServers() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithResponseHeaderTimeout(10*time.Millisecond))
+
+	// Act
+	response, err := client.Get(s.ctx, "/")
+
+	// Assert
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *TestTransportSuite) Test_WithHTTP2_ShouldSelectProtocol() {
+	// Arrange
+	svc := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	svc.EnableHTTP2 = true
+	svc.StartTLS()
+	defer svc.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svc.Certificate().Raw})
+	cases := map[bool]string{true: "HTTP/2.0", false: "HTTP/1.1"}
+
+	for enabled, proto := range cases {
+		s.Suite.Run(proto, func() {
+			client := New(svc.URL, WithRootCAs(ca), WithHTTP2(enabled))
+
+			// Act
+			response, err := client.Get(s.ctx, "/")
+
+			// Assert
+			s.NoError(err)
+			s.Equal(proto, string(response.Body()))
+		})
+	}
+}
+
+func (s *TestTransportSuite) Test_Close_ShouldReleaseIdleConnections() {
+	// Arrange
+	var closed int32
+	svc := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	svc.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			atomic.AddInt32(&closed, 1)
+		}
+	}
+	svc.Start()
+	defer svc.Close()
+
+	client := New(svc.URL)
+	_, err := client.Get(s.ctx, "/")
+	s.Require().NoError(err)
+
+	// Act
+	err = client.Close()
+
+	// Assert
+	s.NoError(err)
+	s.Eventually(func() bool { return atomic.LoadInt32(&closed) == 1 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,381 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// WebSocketConn is a client side websocket connection opened by Client.WebSocket
+	WebSocketConn struct {
+		rwc         io.ReadWriteCloser
+		br          *bufio.Reader
+		subprotocol string
+
+		writeMu     sync.Mutex
+		closeOnce   sync.Once
+		pongHandler func(data []byte)
+	}
+
+	// CloseError is returned by ReadMessage when the peer closed the connection
+	CloseError struct {
+		Code   int
+		Reason string
+	}
+
+	frame struct {
+		fin     bool
+		opcode  int
+		payload []byte
+	}
+)
+
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+
+	continuationFrame = 0
+)
+
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseNoStatusReceived = 1005
+)
+
+const (
+	MAX_WEBSOCKET_MESSAGE_SIZE = 32 << 20
+
+	websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+var (
+	ErrBadHandshake = errors.New("websocket: bad handshake")
+)
+
+// WebSocket func performs the upgrade handshake on the client base url with the client headers.
+// The handshake goes through the client http.Client, so its transport, TLS and proxy settings apply.
+// The base url may use the http(s) or ws(s) scheme.
+func (c *Client) WebSocket(ctx context.Context, endpoint string, opts ...Option) (*WebSocketConn, error) {
+	rc := c.withOpts(opts...)
+
+	target := rc.baseUrl + endpoint
+	switch {
+	case strings.HasPrefix(target, "ws://"):
+		target = "http://" + strings.TrimPrefix(target, "ws://")
+	case strings.HasPrefix(target, "wss://"):
+		target = "https://" + strings.TrimPrefix(target, "wss://")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate websocket key")
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req, err = rc.prepareReq(req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+
+	res, err := rc.streamingClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+
+	rwc, ok := res.Body.(io.ReadWriteCloser)
+	if res.StatusCode != http.StatusSwitchingProtocols || !ok {
+		res.Body.Close()
+		return nil, errors.Wrapf(ErrBadHandshake, "unexpected status code %d", res.StatusCode)
+	}
+
+	if !strings.EqualFold(res.Header.Get("Upgrade"), "websocket") ||
+		res.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		rwc.Close()
+		return nil, errors.Wrap(ErrBadHandshake, "invalid upgrade response")
+	}
+
+	return &WebSocketConn{
+		rwc:         rwc,
+		br:          bufio.NewReader(rwc),
+		subprotocol: res.Header.Get("Sec-WebSocket-Protocol"),
+	}, nil
+}
+
+// Subprotocol func returns the protocol the server selected from Sec-WebSocket-Protocol
+func (ws *WebSocketConn) Subprotocol() string {
+	return ws.subprotocol
+}
+
+// SetPongHandler sets a function who is called with the payload of every pong
+func (ws *WebSocketConn) SetPongHandler(handler func(data []byte)) {
+	ws.pongHandler = handler
+}
+
+// ReadMessage func returns the next text or binary message. Pings are answered automatically,
+// a close frame from the peer is answered and returned as a *CloseError.
+func (ws *WebSocketConn) ReadMessage() (int, []byte, error) {
+	var (
+		messageType int
+		message     []byte
+	)
+
+	for {
+		f, err := readFrame(ws.br, false)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch f.opcode {
+		case PingMessage:
+			if err := ws.writeFrame(PongMessage, f.payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			if ws.pongHandler != nil {
+				ws.pongHandler(f.payload)
+			}
+			continue
+		case CloseMessage:
+			// 1005 must not be sent, a close frame without a code is answered by an empty one
+			var payload []byte
+			closeErr := &CloseError{Code: CloseNoStatusReceived}
+			if len(f.payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(f.payload))
+				closeErr.Reason = string(f.payload[2:])
+				payload = f.payload[:2]
+			}
+
+			ws.close(payload)
+			return 0, nil, closeErr
+		case continuationFrame:
+			if messageType == 0 {
+				return 0, nil, errors.New("websocket: continuation frame without a message")
+			}
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, errors.New("websocket: new message before the previous one finished")
+			}
+			messageType = f.opcode
+		default:
+			return 0, nil, errors.Errorf("websocket: unknown opcode %d", f.opcode)
+		}
+
+		if len(message)+len(f.payload) > MAX_WEBSOCKET_MESSAGE_SIZE {
+			return 0, nil, errors.New("websocket: message exceeds the maximum message size")
+		}
+
+		message = append(message, f.payload...)
+		if !f.fin {
+			continue
+		}
+
+		if messageType == TextMessage && !utf8.Valid(message) {
+			return 0, nil, errors.New("websocket: text message is not valid utf-8")
+		}
+
+		return messageType, message, nil
+	}
+}
+
+// WriteMessage func sends data as a single text or binary frame
+func (ws *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return errors.Errorf("websocket: %d is not a data message type", messageType)
+	}
+
+	return ws.writeFrame(messageType, data)
+}
+
+// Ping func sends a ping frame, the pong is delivered to the pong handler by ReadMessage
+func (ws *WebSocketConn) Ping(data []byte) error {
+	return ws.writeFrame(PingMessage, data)
+}
+
+// Close func sends a normal closure frame and closes the connection
+func (ws *WebSocketConn) Close() error {
+	return ws.CloseWithStatus(CloseNormalClosure, "")
+}
+
+// CloseWithStatus func sends a close frame with the code and reason and closes the connection
+func (ws *WebSocketConn) CloseWithStatus(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	return ws.close(append(payload, reason...))
+}
+
+// close sends a close frame with payload and closes the connection, only the first call does anything
+func (ws *WebSocketConn) close(payload []byte) error {
+	var err error
+	ws.closeOnce.Do(func() {
+		writeErr := ws.writeFrame(CloseMessage, payload)
+		err = ws.rwc.Close()
+		if writeErr != nil {
+			err = writeErr
+		}
+	})
+
+	return err
+}
+
+func (ws *WebSocketConn) writeFrame(opcode int, payload []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	return writeFrame(ws.rwc, frame{fin: true, opcode: opcode, payload: payload}, true)
+}
+
+func (e *CloseError) Error() string {
+	if e.Reason == "" {
+		return "websocket: closed with code " + strconv.Itoa(e.Code)
+	}
+
+	return "websocket: closed with code " + strconv.Itoa(e.Code) + ": " + e.Reason
+}
+
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// readFrame reads a single frame, masked tells whether the peer must mask it. A client must fail
+// on masked frames from the server and a server on unmasked frames from a client.
+func readFrame(r io.Reader, masked bool) (frame, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return frame{}, err
+	}
+
+	if header[0]&0x70 != 0 {
+		return frame{}, errors.New("websocket: reserved bits are set")
+	}
+
+	f := frame{fin: header[0]&0x80 != 0, opcode: int(header[0] & 0x0f)}
+	if masked != (header[1]&0x80 != 0) {
+		if masked {
+			return frame{}, errors.New("websocket: frame from the client is not masked")
+		}
+
+		return frame{}, errors.New("websocket: frame from the server is masked")
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return frame{}, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return frame{}, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if f.opcode >= CloseMessage && (length > 125 || !f.fin) {
+		return frame{}, errors.New("websocket: invalid control frame")
+	}
+
+	if length > MAX_WEBSOCKET_MESSAGE_SIZE {
+		return frame{}, errors.New("websocket: frame exceeds the maximum message size")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return frame{}, err
+		}
+	}
+
+	f.payload = make([]byte, length)
+	if _, err := io.ReadFull(r, f.payload); err != nil {
+		return frame{}, err
+	}
+
+	if masked {
+		for i := range f.payload {
+			f.payload[i] ^= mask[i%4]
+		}
+	}
+
+	return f, nil
+}
+
+// writeFrame writes a single frame, frames sent by a client must be masked
+func writeFrame(w io.Writer, f frame, masked bool) error {
+	header := make([]byte, 0, 14)
+
+	first := byte(f.opcode)
+	if f.fin {
+		first |= 0x80
+	}
+	header = append(header, first)
+
+	var maskBit byte
+	if masked {
+		maskBit = 0x80
+	}
+
+	length := len(f.payload)
+	switch {
+	case length <= 125:
+		header = append(header, maskBit|byte(length))
+	case length <= 0xffff:
+		header = append(header, maskBit|126, 0, 0)
+		binary.BigEndian.PutUint16(header[len(header)-2:], uint16(length))
+	default:
+		header = append(header, maskBit|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[len(header)-8:], uint64(length))
+	}
+
+	payload := f.payload
+	if masked {
+		var mask [4]byte
+		if _, err := io.ReadFull(rand.Reader, mask[:]); err != nil {
+			return err
+		}
+		header = append(header, mask[:]...)
+
+		payload = make([]byte, length)
+		for i := range f.payload {
+			payload[i] = f.payload[i] ^ mask[i%4]
+		}
+	}
+
+	if _, err := w.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	return nil
+}
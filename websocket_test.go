@@ -0,0 +1,237 @@
+package gohttpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestWebSocketSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestWebSocket(t *testing.T) {
+	suite.Run(t, new(TestWebSocketSuite))
+}
+
+func (s *TestWebSocketSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+// newWebSocketServer upgrades every request and hands the raw connection to serve
+func newWebSocketServer(serve func(conn net.Conn, br *bufio.Reader, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+		rw.WriteString("Upgrade: websocket\r\nConnection: Upgrade\r\n")
+		rw.WriteString("Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n")
+		if protocol := r.Header.Get("Sec-WebSocket-Protocol"); protocol != "" {
+			rw.WriteString("Sec-WebSocket-Protocol: " + protocol + "\r\n")
+		}
+		rw.WriteString("\r\n")
+		rw.Flush()
+
+		serve(conn, rw.Reader, r)
+	}))
+}
+
+func (s *TestWebSocketSuite) Test_WebSocket_ShouldEchoMessages() {
+	// Arrange
+	svc := newWebSocketServer(func(conn net.Conn, br *bufio.Reader, r *http.Request) {
+		for {
+			f, err := readFrame(br, true)
+			if err != nil || f.opcode == CloseMessage {
+				return
+			}
+
+			writeFrame(conn, f, false)
+		}
+	})
+	defer svc.Close()
+
+	client := New(svc.URL, WithDefaultHeaders())
+
+	// Act
+	conn, err := client.WebSocket(s.ctx, "/ws", WithHeader("Sec-WebSocket-Protocol", "chat"))
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	s.NoError(conn.WriteMessage(TextMessage, []byte("hello")))
+	textType, text, textErr := conn.ReadMessage()
+
+	large := []byte(strings.Repeat("x", 70000))
+	s.NoError(conn.WriteMessage(BinaryMessage, large))
+	binaryType, binaryData, binaryErr := conn.ReadMessage()
+
+	// Assert
+	s.Equal("chat", conn.Subprotocol())
+	s.NoError(textErr)
+	s.Equal(TextMessage, textType)
+	s.Equal("hello", string(text))
+	s.NoError(binaryErr)
+	s.Equal(BinaryMessage, binaryType)
+	s.Equal(large, binaryData)
+}
+
+func (s *TestWebSocketSuite) Test_WebSocket_ShouldUseClientHeaders() {
+	// Arrange
+	headers := make(chan http.Header, 1)
+	svc := newWebSocketServer(func(conn net.Conn, br *bufio.Reader, r *http.Request) {
+		headers <- r.Header
+	})
+	defer svc.Close()
+
+	client := New("ws"+strings.TrimPrefix(svc.URL, "http"), WithDefaultHeaders())
+
+	// Act
+	conn, err := client.WebSocket(s.ctx, "/ws", WithHeader("Authorization", "Bearer token"))
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	// Assert
+	header := <-headers
+	s.Equal("Bearer token", header.Get("Authorization"))
+	s.Equal("application/json", header.Get("Accept"))
+	s.Equal("13", header.Get("Sec-WebSocket-Version"))
+}
+
+func (s *TestWebSocketSuite) Test_ReadMessage_ShouldHandleControlFrames() {
+	// Arrange
+	pongs := make(chan string, 1)
+	svc := newWebSocketServer(func(conn net.Conn, br *bufio.Reader, r *http.Request) {
+		f, _ := readFrame(br, true)
+		writeFrame(conn, frame{fin: true, opcode: PongMessage, payload: f.payload}, false)
+
+		writeFrame(conn, frame{fin: true, opcode: PingMessage, payload: []byte("ping")}, false)
+		f, _ = readFrame(br, true)
+		pongs <- string(f.payload)
+
+		writeFrame(conn, frame{fin: false, opcode: TextMessage, payload: []byte("frag")}, false)
+		writeFrame(conn, frame{fin: true, opcode: continuationFrame, payload: []byte("ment")}, false)
+
+		payload := make([]byte, 2)
+		binary.BigEndian.PutUint16(payload, CloseGoingAway)
+		writeFrame(conn, frame{fin: true, opcode: CloseMessage, payload: append(payload, "bye"...)}, false)
+		readFrame(br, true)
+	})
+	defer svc.Close()
+
+	client := New(svc.URL)
+	conn, err := client.WebSocket(s.ctx, "/ws")
+	s.Require().NoError(err)
+
+	var pong string
+	conn.SetPongHandler(func(data []byte) { pong = string(data) })
+
+	// Act
+	s.NoError(conn.Ping([]byte("are you there")))
+	messageType, message, err := conn.ReadMessage()
+	_, _, closeErr := conn.ReadMessage()
+
+	// Assert
+	s.NoError(err)
+	s.Equal("ping", <-pongs)
+	s.Equal("are you there", pong)
+	s.Equal(TextMessage, messageType)
+	s.Equal("fragment", string(message))
+
+	var wsCloseErr *CloseError
+	s.True(errors.As(closeErr, &wsCloseErr))
+	s.Equal(CloseGoingAway, wsCloseErr.Code)
+	s.Equal("bye", wsCloseErr.Reason)
+}
+
+func (s *TestWebSocketSuite) Test_ReadMessage_WhenCloseFrameHasNoCode_ShouldAnswerWithEmptyCloseFrame() {
+	// Arrange
+	answers := make(chan frame, 1)
+	svc := newWebSocketServer(func(conn net.Conn, br *bufio.Reader, r *http.Request) {
+		writeFrame(conn, frame{fin: true, opcode: CloseMessage}, false)
+		f, _ := readFrame(br, true)
+		answers <- f
+	})
+	defer svc.Close()
+
+	client := New(svc.URL)
+	conn, err := client.WebSocket(s.ctx, "/ws")
+	s.Require().NoError(err)
+
+	// Act
+	_, _, closeErr := conn.ReadMessage()
+
+	// Assert
+	var wsCloseErr *CloseError
+	s.True(errors.As(closeErr, &wsCloseErr))
+	s.Equal(CloseNoStatusReceived, wsCloseErr.Code)
+
+	answer := <-answers
+	s.Equal(CloseMessage, answer.opcode)
+	s.Empty(answer.payload)
+}
+
+func (s *TestWebSocketSuite) Test_ReadMessage_WhenServerFrameIsMasked_ShouldReturnError() {
+	// Arrange
+	svc := newWebSocketServer(func(conn net.Conn, br *bufio.Reader, r *http.Request) {
+		writeFrame(conn, frame{fin: true, opcode: TextMessage, payload: []byte("masked")}, true)
+		readFrame(br, true)
+	})
+	defer svc.Close()
+
+	client := New(svc.URL)
+	conn, err := client.WebSocket(s.ctx, "/ws")
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	// Act
+	_, message, err := conn.ReadMessage()
+
+	// Assert
+	s.Nil(message)
+	s.EqualError(err, "websocket: frame from the server is masked")
+}
+
+func (s *TestWebSocketSuite) Test_WebSocket_WhenServerRefusesUpgrade_ShouldReturnError() {
+	// Arrange
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL)
+
+	// Act
+	conn, err := client.WebSocket(s.ctx, "/ws")
+
+	// Assert
+	s.Nil(conn)
+	s.True(errors.Is(err, ErrBadHandshake))
+}
+
+func (s *TestWebSocketSuite) Test_WriteMessage_WhenTypeIsNotData_ShouldReturnError() {
+	// Arrange
+	conn := &WebSocketConn{}
+
+	// Act
+	err := conn.WriteMessage(PingMessage, nil)
+
+	// Assert
+	s.Error(err)
+}
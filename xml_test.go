@@ -0,0 +1,136 @@
+package gohttpclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestXMLSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+type xmlPartner struct {
+	Name string `xml:"name"`
+}
+
+func TestXML(t *testing.T) {
+	suite.Run(t, new(TestXMLSuite))
+}
+
+func (s *TestXMLSuite) SetupSuite() {
+	s.ctx = context.Background()
+}
+
+func (s *TestXMLSuite) Test_WithXMLBody_ShouldSendXML() {
+	// Arrange
+	received := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received <- r
+		bodies <- string(body)
+	}))
+	defer svc.Close()
+
+	client := New(svc.URL, WithDefaultHeaders())
+
+	// Act
+	_, err := client.Post(s.ctx, "/", WithXMLBody(xmlPartner{Name: "Zoë"}))
+
+	// Assert
+	s.NoError(err)
+	r := <-received
+	s.Equal("application/xml; charset=utf-8", r.Header.Get("Content-Type"))
+	s.Equal("application/xml", r.Header.Get("Accept"))
+	s.Equal(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<xmlPartner><name>Zoë</name></xmlPartner>`, <-bodies)
+}
+
+func (s *TestXMLSuite) Test_WithXMLBody_WhenAcceptIsSet_ShouldKeepIt() {
+	// Arrange
+	client := New("http://localhost:8080")
+
+	// Act
+	rc := client.withOpts(WithHeader("Accept", "text/xml"), WithXMLBody(xmlPartner{}))
+
+	// Assert
+	s.Equal("text/xml", rc.headers["Accept"].Value)
+}
+
+func (s *TestXMLSuite) Test_WithDefaultXMLHeaders_ShouldRunSuccesfully() {
+	// Arrange
+	client := New("http://localhost:8080")
+
+	// Act
+	WithDefaultXMLHeaders()(client)
+
+	// Assert
+	s.Equal(Header{Value: "application/xml; charset=utf-8", IsDefault: true}, client.headers["Content-Type"])
+	s.Equal(Header{Value: "application/xml", IsDefault: true}, client.headers["Accept"])
+}
+
+func (s *TestXMLSuite) Test_UnmarshalXml_ShouldDetectCharset() {
+	// Arrange
+	latin1 := []byte("<partner><name>Zo\xeb</name></partner>")
+	cases := map[string]struct {
+		contentType string
+		body        []byte
+	}{
+		"content type charset": {contentType: "text/xml; charset=ISO-8859-1", body: latin1},
+		"xml declaration":      {contentType: "application/xml", body: append([]byte(`<?xml version="1.0" encoding="ISO-8859-1"?>`), latin1...)},
+		"both":                 {contentType: "text/xml; charset=iso-8859-1", body: append([]byte(`<?xml version="1.0" encoding="ISO-8859-1"?>`), latin1...)},
+		"utf-8":                {contentType: "application/xml", body: []byte("<partner><name>Zoë</name></partner>")},
+	}
+
+	for name, tc := range cases {
+		s.Suite.Run(name, func() {
+			res := &http.Response{Header: http.Header{"Content-Type": []string{tc.contentType}}}
+			resp := Response{res: res, body: tc.body}
+
+			// Act
+			var partner xmlPartner
+			err := resp.UnmarshalXml(&partner)
+
+			var decoded xmlPartner
+			decodeErr := resp.Decode(&decoded)
+
+			// Assert
+			s.NoError(err)
+			s.NoError(decodeErr)
+			s.Equal("Zoë", partner.Name)
+			s.Equal("Zoë", decoded.Name)
+		})
+	}
+}
+
+func (s *TestXMLSuite) Test_UnmarshalXml_WhenCharsetIsUnsupported_ShouldReturnError() {
+	// Arrange
+	res := &http.Response{Header: http.Header{"Content-Type": []string{"application/xml; charset=shift_jis"}}}
+	resp := Response{res: res, body: []byte("<partner/>")}
+
+	// Act
+	var partner xmlPartner
+	err := resp.UnmarshalXml(&partner)
+
+	// Assert
+	s.EqualError(err, `unsupported charset "shift_jis"`)
+}
+
+func (s *TestXMLSuite) Test_Decode_WhenJSONIsLatin1_ShouldConvertCharset() {
+	// Arrange
+	res := &http.Response{Header: http.Header{"Content-Type": []string{"application/json; charset=latin1"}}}
+	resp := Response{res: res, body: []byte("{\"name\":\"Zo\xeb\"}")}
+
+	// Act
+	var partner map[string]string
+	err := resp.Decode(&partner)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("Zoë", partner["name"])
+}